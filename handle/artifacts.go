@@ -0,0 +1,185 @@
+package handle
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// maxConsoleMessages 每个标签页保留的最近控制台消息数
+const maxConsoleMessages = 200
+
+// ArtifactOptions 失败现场采集配置
+type ArtifactOptions struct {
+	Dir     string        // 保存目录，默认 当前目录/artifacts
+	Zip     bool          // 打包为 zip 文件，否则保存为目录
+	Timeout time.Duration // 采集耗时上限，默认 15 秒
+}
+
+// ArtifactError 操作失败的错误，附带失败现场的保存位置
+type ArtifactError struct {
+	Op     string // 失败的操作
+	Bundle string // 现场目录或 zip 文件路径，采集失败时为空
+	Err    error  // 原始错误
+}
+
+func (e *ArtifactError) Error() string {
+	if e.Bundle == "" {
+		return fmt.Sprintf("%s: %v", e.Op, e.Err)
+	}
+	return fmt.Sprintf("%s: %v (现场已保存: %s)", e.Op, e.Err, e.Bundle)
+}
+
+func (e *ArtifactError) Unwrap() error {
+	return e.Err
+}
+
+// ConsoleMessage 页面控制台消息
+type ConsoleMessage struct {
+	Type string    `json:"type"`
+	Text string    `json:"text"`
+	URL  string    `json:"url"`
+	Time time.Time `json:"time"`
+}
+
+// consoleLog 保存最近的控制台消息
+type consoleLog struct {
+	locker   sync.Mutex
+	messages []ConsoleMessage
+}
+
+func (c *consoleLog) append(message playwright.ConsoleMessage) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	item := ConsoleMessage{Type: message.Type(), Text: message.Text(), Time: time.Now()}
+	if location := message.Location(); location != nil {
+		item.URL = location.URL
+	}
+	c.messages = append(c.messages, item)
+	if len(c.messages) > maxConsoleMessages {
+		c.messages = c.messages[len(c.messages)-maxConsoleMessages:]
+	}
+}
+
+func (c *consoleLog) list() []ConsoleMessage {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	return append([]ConsoleMessage(nil), c.messages...)
+}
+
+// artifactSeq 现场目录名中的序号
+var artifactSeq atomic.Int64
+
+// capture_artifacts 采集页面现场并保存，返回目录或 zip 路径
+func capture_artifacts(page playwright.Page, tabID string, reason error, console []ConsoleMessage, opts ArtifactOptions) (string, error) {
+	if opts.Dir == "" {
+		opts.Dir = "artifacts"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 15 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+
+	files := make(map[string][]byte)
+	var errs []error
+
+	files["url.txt"] = []byte(page.URL())
+	if reason != nil {
+		files["error.txt"] = []byte(reason.Error())
+	}
+	if screenshot, err := take_screenshot(ctx, page, ScreenshotOptions{FullPage: true}); err == nil {
+		files["screenshot.png"] = screenshot
+	} else {
+		errs = append(errs, err)
+	}
+	// 页面卡死时 Content、Cookies 也可能一直不返回，与截图共用采集时限
+	if html, err := evaluate_with_ctx(ctx, func() (any, error) { return page.Content() }); err == nil {
+		files["page.html"] = []byte(html.(string))
+	} else {
+		errs = append(errs, fmt.Errorf("无法获取页面内容: %w", err))
+	}
+	if cookies, err := evaluate_with_ctx(ctx, func() (any, error) { return page.Context().Cookies() }); err == nil {
+		data, _ := json.MarshalIndent(cookies, "", "  ")
+		files["cookies.json"] = data
+	} else {
+		errs = append(errs, fmt.Errorf("无法获取 Cookies: %w", err))
+	}
+	var sb strings.Builder
+	for _, message := range console {
+		fmt.Fprintf(&sb, "%s [%s] %s (%s)\n", message.Time.Format("15:04:05.000"), message.Type, message.Text, message.URL)
+	}
+	files["console.log"] = []byte(sb.String())
+
+	// 序号避免同一毫秒内同名标签页的现场互相覆盖
+	name := fmt.Sprintf("%s_%s_%d", time.Now().Format("20060102_150405.000"), sanitize_file_name(tabID), artifactSeq.Add(1))
+	if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+		return "", fmt.Errorf("无法创建现场目录: %w", err)
+	}
+
+	var bundle string
+	if opts.Zip {
+		bundle = filepath.Join(opts.Dir, name+".zip")
+		if err := write_zip(bundle, files); err != nil {
+			return "", err
+		}
+	} else {
+		bundle = filepath.Join(opts.Dir, name)
+		if err := os.MkdirAll(bundle, 0755); err != nil {
+			return "", fmt.Errorf("无法创建现场目录: %w", err)
+		}
+		for file, data := range files {
+			if err := os.WriteFile(filepath.Join(bundle, file), data, 0644); err != nil {
+				return "", fmt.Errorf("无法保存现场文件 %s: %w", file, err)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		log.Printf("现场采集不完整: %v", errors.Join(errs...))
+	}
+	log.Printf("失败现场已保存: %s", bundle)
+	return bundle, nil
+}
+
+func write_zip(path string, files map[string][]byte) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("无法创建 zip 文件: %w", err)
+	}
+	defer file.Close()
+
+	writer := zip.NewWriter(file)
+	for name, data := range files {
+		w, err := writer.Create(name)
+		if err != nil {
+			return fmt.Errorf("无法写入 zip 文件: %w", err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("无法写入 zip 文件: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("无法写入 zip 文件: %w", err)
+	}
+	return nil
+}
+
+func sanitize_file_name(name string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`<>:"/\|?* `, r) {
+			return '_'
+		}
+		return r
+	}, name)
+}
@@ -0,0 +1,87 @@
+package handle
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// BindingCall 页面 JS 对 Go 函数的一次调用
+type BindingCall struct {
+	Name  string            // 函数名
+	TabID string            // 发起调用的标签页ID，无法识别时为空
+	URL   string            // 发起调用的 frame 地址
+	Args  []json.RawMessage // JSON 编码的参数
+}
+
+// Arg 将第 i 个参数 JSON 解码到 out
+func (c *BindingCall) Arg(i int, out any) error {
+	if i < 0 || i >= len(c.Args) {
+		return fmt.Errorf("函数 %s 缺少第 %d 个参数", c.Name, i+1)
+	}
+	if err := json.Unmarshal(c.Args[i], out); err != nil {
+		return fmt.Errorf("无法解析函数 %s 的第 %d 个参数: %w", c.Name, i+1, err)
+	}
+	return nil
+}
+
+// BindingFunc 暴露给页面的 Go 函数，返回值按 JSON 序列化后传回页面，
+// 返回错误时页面中的 Promise 被 reject；每次调用在独立的协程中执行，可能并发，
+// 到达 Go 的顺序也不保证与页面中的调用顺序一致，需要保序时(如 MutationObserver 的事件流)
+// 由页面在参数中携带序号，或等待上一次调用的 Promise 完成后再发起下一次
+type BindingFunc func(call *BindingCall) (any, error)
+
+// Bind 将单参数的强类型函数包装为 BindingFunc，页面调用时传入的第一个参数解码为 A
+func Bind[A any, R any](fn func(arg A) (R, error)) BindingFunc {
+	return func(call *BindingCall) (any, error) {
+		var arg A
+		if len(call.Args) > 0 {
+			if err := call.Arg(0, &arg); err != nil {
+				return nil, err
+			}
+		}
+		return fn(arg)
+	}
+}
+
+// binding_callback 转换为 Playwright 的绑定函数，tabID 根据调用来源的页面查找标签页ID
+func binding_callback(name string, fn BindingFunc, tabID func(page playwright.Page) string) playwright.BindingCallFunction {
+	return func(source *playwright.BindingSource, args ...any) any {
+		call := &BindingCall{Name: name, Args: make([]json.RawMessage, 0, len(args))}
+		if source.Frame != nil {
+			call.URL = source.Frame.URL()
+		}
+		if source.Page != nil {
+			call.TabID = tabID(source.Page)
+		}
+		for _, arg := range args {
+			data, err := json.Marshal(arg)
+			if err != nil {
+				panic(fmt.Errorf("无法序列化函数 %s 的参数: %w", name, err))
+			}
+			call.Args = append(call.Args, data)
+		}
+
+		result, err := call_binding(fn, call)
+		if err != nil {
+			// Playwright 将 panic 的 error 转换为页面中 reject 的 Promise
+			panic(err)
+		}
+		value, err := js_value(result)
+		if err != nil {
+			panic(err)
+		}
+		return value
+	}
+}
+
+// call_binding 执行用户函数，将其中的 panic 转换为错误
+func call_binding(fn BindingFunc, call *BindingCall) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("函数 %s 执行异常: %v", call.Name, r)
+		}
+	}()
+	return fn(call)
+}
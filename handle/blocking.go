@@ -0,0 +1,251 @@
+package handle
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// BlockPreset 资源屏蔽预设
+type BlockPreset string
+
+const (
+	BlockImages         BlockPreset = "images"          // 图片
+	BlockMedia          BlockPreset = "media"           // 音视频
+	BlockFonts          BlockPreset = "fonts"           // 字体
+	BlockStylesheets    BlockPreset = "stylesheets"     // 样式表
+	BlockTrackers       BlockPreset = "trackers"        // 广告与统计域名
+	BlockServiceWorkers BlockPreset = "service-workers" // Service Worker 注册，屏蔽后不能取消
+)
+
+// AllBlockPresets 返回全部屏蔽预设
+func AllBlockPresets() []BlockPreset {
+	return []BlockPreset{BlockImages, BlockMedia, BlockFonts, BlockStylesheets, BlockTrackers, BlockServiceWorkers}
+}
+
+//go:embed tracker_domains.txt
+var bundledTrackerDomains string
+
+var (
+	blockedDomains       = parse_domain_list(strings.NewReader(bundledTrackerDomains))
+	blockedDomainsLocker sync.RWMutex
+)
+
+// SetBlockedDomains 替换广告与统计域名列表，已生效的屏蔽规则立即使用新列表
+func SetBlockedDomains(domains []string) {
+	set := make(map[string]struct{}, len(domains))
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain != "" {
+			set[domain] = struct{}{}
+		}
+	}
+	blockedDomainsLocker.Lock()
+	defer blockedDomainsLocker.Unlock()
+	blockedDomains = set
+}
+
+// LoadBlockedDomains 从文件加载域名列表，每行一个域名，支持 # 注释和 hosts 文件格式
+func LoadBlockedDomains(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("无法打开域名列表: %w", err)
+	}
+	defer file.Close()
+
+	set := parse_domain_list(file)
+	blockedDomainsLocker.Lock()
+	defer blockedDomainsLocker.Unlock()
+	blockedDomains = set
+	return nil
+}
+
+// BlockedDomains 返回当前的广告与统计域名列表
+func BlockedDomains() []string {
+	blockedDomainsLocker.RLock()
+	defer blockedDomainsLocker.RUnlock()
+
+	domains := make([]string, 0, len(blockedDomains))
+	for domain := range blockedDomains {
+		domains = append(domains, domain)
+	}
+	return domains
+}
+
+func parse_domain_list(r io.Reader) map[string]struct{} {
+	set := make(map[string]struct{})
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		// hosts 文件格式: 0.0.0.0 example.com
+		domain := fields[len(fields)-1]
+		set[strings.ToLower(domain)] = struct{}{}
+	}
+	return set
+}
+
+func is_blocked_domain(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+
+	blockedDomainsLocker.RLock()
+	defer blockedDomainsLocker.RUnlock()
+	for host != "" {
+		if _, ok := blockedDomains[host]; ok {
+			return true
+		}
+		i := strings.Index(host, ".")
+		if i < 0 {
+			break
+		}
+		host = host[i+1:]
+	}
+	return false
+}
+
+func block_preset_matcher(preset BlockPreset) (RouteMatcher, error) {
+	switch preset {
+	case BlockImages:
+		return RouteMatcher{ResourceTypes: []string{"image"}}, nil
+	case BlockMedia:
+		return RouteMatcher{ResourceTypes: []string{"media"}}, nil
+	case BlockFonts:
+		return RouteMatcher{ResourceTypes: []string{"font"}}, nil
+	case BlockStylesheets:
+		return RouteMatcher{ResourceTypes: []string{"stylesheet"}}, nil
+	case BlockTrackers:
+		return RouteMatcher{Predicate: func(req *RouteRequest) bool { return is_blocked_domain(req.URL) }}, nil
+	}
+	return RouteMatcher{}, fmt.Errorf("未知的屏蔽预设: %s", preset)
+}
+
+// blockRulePrefix 屏蔽预设在路由表中的名称前缀，用户规则不能使用
+const blockRulePrefix = "block:"
+
+func block_rule_name(preset BlockPreset) string {
+	return blockRulePrefix + string(preset)
+}
+
+// blockServiceWorkerScript 替换 navigator.serviceWorker.register，注册时通知 Go 计数并返回失败
+const blockServiceWorkerScript = `((binding) => {
+	const container = globalThis.navigator && navigator.serviceWorker;
+	if (!container || container.__blocked) return;
+	Object.defineProperty(container, "__blocked", { value: true });
+	container.register = async function () {
+		try {
+			await globalThis[binding]();
+		} catch (e) {}
+		throw new DOMException("Service Worker 已被屏蔽", "SecurityError");
+	};
+})(%q)`
+
+// serviceWorkerTarget 页面或上下文
+type serviceWorkerTarget interface {
+	AddInitScript(script playwright.Script) error
+	ExposeBinding(name string, binding playwright.BindingCallFunction, handle ...bool) error
+}
+
+// serviceWorkerBlock Service Worker 脚本的请求不经过页面和上下文的路由，改为在页面中替换注册函数；
+// 初始化脚本无法移除，屏蔽后不能取消
+type serviceWorkerBlock struct {
+	locker  sync.Mutex
+	blocked bool
+	binding string                   // 页面通知 Go 计数的绑定函数名，页面和上下文各用一个
+	target  serviceWorkerTarget      // 添加初始化脚本和绑定函数
+	pages   func() []playwright.Page // 需要立即生效的现有页面
+}
+
+func (w *serviceWorkerBlock) block(routes *routeTable) error {
+	w.locker.Lock()
+	defer w.locker.Unlock()
+
+	if w.blocked {
+		return nil
+	}
+	counter := routes.counter(block_rule_name(BlockServiceWorkers))
+	err := w.target.ExposeBinding(w.binding, func(source *playwright.BindingSource, args ...any) any {
+		counter.Add(1)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("无法屏蔽 Service Worker: %w", err)
+	}
+	script := fmt.Sprintf(blockServiceWorkerScript, w.binding)
+	if err := w.target.AddInitScript(playwright.Script{Content: playwright.String(script)}); err != nil {
+		return fmt.Errorf("无法屏蔽 Service Worker: %w", err)
+	}
+	for _, page := range w.pages() {
+		if _, err := page.Evaluate(script); err != nil {
+			log.Printf("无法在现有页面中屏蔽 Service Worker: %v", err)
+		}
+	}
+	w.blocked = true
+	return nil
+}
+
+func block_resources(routes *routeTable, workers *serviceWorkerBlock, presets []BlockPreset) error {
+	for _, preset := range presets {
+		if preset == BlockServiceWorkers {
+			if err := workers.block(routes); err != nil {
+				return err
+			}
+			continue
+		}
+		matcher, err := block_preset_matcher(preset)
+		if err != nil {
+			return err
+		}
+		if routes.hits(block_rule_name(preset)) >= 0 {
+			continue
+		}
+		// 屏蔽规则放在同一路由表的最前，避免先注册的 ContinueRoute 等规则将请求放行
+		if err := routes.prepend(block_rule_name(preset), matcher, AbortRoute("blockedbyclient")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func unblock_resources(routes *routeTable, presets []BlockPreset) error {
+	for _, preset := range presets {
+		if preset == BlockServiceWorkers {
+			return fmt.Errorf("%w: Service Worker 屏蔽通过初始化脚本实现，无法取消", ErrUnsupported)
+		}
+		if routes.hits(block_rule_name(preset)) < 0 {
+			continue
+		}
+		if err := routes.remove(block_rule_name(preset)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blocked_counts 返回每个启用过的预设累计屏蔽的请求数，取消屏蔽后再次屏蔽时继续累计
+func blocked_counts(routes *routeTable) map[BlockPreset]int64 {
+	counts := make(map[BlockPreset]int64)
+	for _, preset := range AllBlockPresets() {
+		if n, ok := routes.total(block_rule_name(preset)); ok {
+			counts[preset] = n
+		}
+	}
+	return counts
+}
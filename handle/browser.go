@@ -1,9 +1,26 @@
 package handle
 
 import (
+	"context"
+	"regexp"
+	"time"
+
 	"github.com/playwright-community/playwright-go"
 )
 
+// Element 页面元素，操作失败时返回 *ElementError；不依赖 Playwright 类型，便于在测试中替换
+type Element interface {
+	Selector() string
+	Click(ctx context.Context) error
+	Fill(ctx context.Context, value string) error
+	Text(ctx context.Context) (string, error)
+	Attr(ctx context.Context, name string) (string, error)
+	Visible(ctx context.Context) (bool, error)
+	BoundingBox(ctx context.Context) (*ClipRect, error)
+	Children(ctx context.Context) ([]Element, error)
+	Screenshot(ctx context.Context, opts ScreenshotOptions) ([]byte, error)
+}
+
 type TabPage interface {
 	ID() string
 	Title() string
@@ -13,17 +30,93 @@ type TabPage interface {
 	IsClosed() bool
 	BringToFront()
 	OpenInNewTab(id string, action func() error, timeout float64) TabPage
-	WaitSelector(selector string, timeout float64) playwright.Locator
-	QuerySelector(selector string) playwright.Locator
-	QuerySelectorAll(selector string) []playwright.Locator
+	WaitSelector(selector string, timeout float64) Element
+	QuerySelector(selector string) Element
+	QuerySelectorAll(selector string) []Element
 	ClearLocalData() error
 	Goto(url string) error
+	Navigate(ctx context.Context, url string, opts NavigateOptions) (*NavigationResult, error)
+	Retry(ctx context.Context, policy RetryPolicy, op func(ctx context.Context) error) error
 	Evaluate(expression string, arg ...any) (any, error)
+	EvaluateInto(ctx context.Context, out any, expression string, args ...any) error
+	CallModule(ctx context.Context, out any, module string, fn string, args ...any) error
+	ExposeFunc(name string, fn BindingFunc) error
+	Extract(ctx context.Context, out any) error
+	ExtractTable(ctx context.Context, selector string) (*Table, error)
+	ExtractList(ctx context.Context, selector string) ([]string, error)
 	Page() playwright.Page
 	Reload() error
 	GetCookies() string
 	ApplyCookies(cookies string) error
 	SleepRandom(min, max int)
+	Route(name string, matcher RouteMatcher, action RouteAction) error
+	Unroute(name string) error
+	RouteNames() []string
+	BlockResources(presets ...BlockPreset) error
+	UnblockResources(presets ...BlockPreset) error
+	BlockedCounts() map[BlockPreset]int64
+	WaitForResponse(ctx context.Context, matcher RouteMatcher, action func() error) (*CapturedResponse, error)
+	RecordResponses(ctx context.Context, matcher RouteMatcher, buffer int) (*ResponseRecorder, error)
+	RecordHAR(path string, opts HAROptions) (*HARRecorder, error)
+	ReplayHAR(path string, opts HARReplayOptions) (func() error, error)
+	ExpectDownload(ctx context.Context, action func() error) (*DownloadResult, error)
+	UploadFiles(ctx context.Context, selector string, paths ...string) error
+	UploadFileData(ctx context.Context, selector string, files ...UploadFile) error
+	DropFiles(ctx context.Context, selector string, files ...UploadFile) error
+	SetDialogPolicy(policy DialogPolicy)
+	ClearDialogPolicy()
+	Dialogs() []DialogRecord
+	Screenshot(ctx context.Context, opts ScreenshotOptions) ([]byte, error)
+	EnableFailureArtifacts(opts ArtifactOptions)
+	DisableFailureArtifacts()
+	CaptureArtifacts(opts ArtifactOptions) (string, error)
+	ConsoleMessages() []ConsoleMessage
+	PDF(ctx context.Context, opts PDFOptions) ([]byte, error)
+	MHTML(ctx context.Context) ([]byte, error)
+	CDP() (*CDPSession, error)
+	EmulateNetwork(ctx context.Context, conditions NetworkConditions) error
+	ClearNetworkEmulation(ctx context.Context) error
+	SetCPUThrottling(ctx context.Context, rate float64) error
+	SetTimezone(ctx context.Context, timezoneID string) error
+	SetLocale(ctx context.Context, locale string) error
+	WaitForElement(ctx context.Context, selector string, state ElementState) (Element, error)
+	WaitForText(ctx context.Context, text string) error
+	WaitForURL(ctx context.Context, glob string) error
+	WaitForURLRegex(ctx context.Context, pattern *regexp.Regexp) error
+	WaitForNetworkIdle(ctx context.Context, idle time.Duration) error
+	WaitForFunction(ctx context.Context, expression string, polling time.Duration, arg ...any) (any, error)
+}
+
+type Session interface {
+	ID() string
+	Context() playwright.BrowserContext
+	Proxy() *ProxyConfig
+	Route(name string, matcher RouteMatcher, action RouteAction) error
+	Unroute(name string) error
+	RouteNames() []string
+	BlockResources(presets ...BlockPreset) error
+	UnblockResources(presets ...BlockPreset) error
+	BlockedCounts() map[BlockPreset]int64
+	RecordHAR(path string, opts HAROptions) (*HARRecorder, error)
+	ReplayHAR(path string, opts HARReplayOptions) (func() error, error)
+	Downloads() *DownloadManager
+	NewTabPage(id string, url string) TabPage
+	TabPages() []TabPage
+	StartTracing(opts TraceOptions) error
+	MarkFailed(err error)
+	Recordings() []string
+	Close() error
+	EmulateNetwork(ctx context.Context, conditions NetworkConditions) error
+	ClearNetworkEmulation(ctx context.Context) error
+	SetCPUThrottling(ctx context.Context, rate float64) error
+	GrantPermissions(origin string, permissions ...Permission) error
+	DenyPermissions(ctx context.Context, origin string, permissions ...Permission) error
+	ClearPermissions(ctx context.Context) error
+	SetGeolocation(location *Geolocation) error
+	SetTimezone(ctx context.Context, timezoneID string) error
+	SetLocale(ctx context.Context, locale string) error
+	RegisterModule(name string, source string) error
+	ExposeFunc(name string, fn BindingFunc) error
 }
 
 type Browser interface {
@@ -32,9 +125,17 @@ type Browser interface {
 	TabPages() []TabPage
 	NewTabPage(id string, url string) TabPage
 	DefaultPage() TabPage
+	Session() Session
+	NewSession(id string, opts SessionOptions) (Session, error)
+	FindSession(id string) Session
+	Sessions() []Session
+	SetProxyProvider(provider ProxyProvider)
+	SetRetryPolicy(policy RetryPolicy)
 	FindTabPage(id string) TabPage
 	SwitchToTabPage(id string) error
 	CloseTabPage(id string) error
 	IsAlive() bool
 	Close() error
+	SetDialogPolicy(policy DialogPolicy)
+	Dialogs() []DialogRecord
 }
@@ -0,0 +1,402 @@
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// CDPSession 标签页的 Chrome DevTools Protocol 会话，用于调用 Playwright 未提供的协议命令
+type CDPSession struct {
+	session playwright.CDPSession
+	locker  sync.Mutex
+	enabled map[string]bool                      // 已启用的协议域
+	events  map[string]*eventHub[map[string]any] // 按事件名分发
+	detach  func(c *CDPSession)                  // 断开后通知所属标签页丢弃缓存
+}
+
+func new_cdp_session(page playwright.Page, detach func(c *CDPSession)) (*CDPSession, error) {
+	if err := require_chromium(page); err != nil {
+		return nil, err
+	}
+	session, err := page.Context().NewCDPSession(page)
+	if err != nil {
+		return nil, fmt.Errorf("无法创建 CDP 会话: %w", err)
+	}
+	c := &CDPSession{
+		session: session,
+		enabled: make(map[string]bool),
+		events:  make(map[string]*eventHub[map[string]any]),
+		detach:  detach,
+	}
+	// 浏览器断开会话(如页面崩溃或被其他调试客户端接管)时也要丢弃缓存，否则 CDP() 一直返回失效的会话
+	session.On("Inspector.detached", func(params map[string]any) {
+		if c.detach != nil {
+			c.detach(c)
+		}
+	})
+	return c, nil
+}
+
+// Send 发送协议命令，返回命令结果；ctx 结束时不再等待结果，但命令可能已被执行
+func (c *CDPSession) Send(ctx context.Context, method string, params map[string]any) (map[string]any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if params == nil {
+		params = map[string]any{}
+	}
+	type reply struct {
+		result any
+		err    error
+	}
+	done := make(chan reply, 1)
+	go func() {
+		result, err := c.session.Send(method, params)
+		done <- reply{result, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-done:
+		if r.err != nil {
+			return nil, fmt.Errorf("CDP 命令 %s 失败: %w", method, r.err)
+		}
+		result, _ := r.result.(map[string]any)
+		return result, nil
+	}
+}
+
+// SendAs 发送协议命令并将结果解码到 out
+func (c *CDPSession) SendAs(ctx context.Context, method string, params map[string]any, out any) error {
+	result, err := c.Send(ctx, method, params)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("无法解析 CDP 命令 %s 的结果: %w", method, err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("无法解析 CDP 命令 %s 的结果: %w", method, err)
+	}
+	return nil
+}
+
+// On 订阅协议事件(如 Network.responseReceived)，返回取消订阅的函数；
+// 需要先启用对应的协议域，handler 在事件分发协程中执行，不能在其中同步调用 Send
+func (c *CDPSession) On(event string, handler func(params map[string]any)) func() {
+	c.locker.Lock()
+	hub, ok := c.events[event]
+	if !ok {
+		hub = newEventHub[map[string]any]()
+		c.events[event] = hub
+		c.session.On(event, func(params map[string]any) {
+			// 协议中 params 为 null 时保证订阅者拿到可写的空表
+			if params == nil {
+				params = map[string]any{}
+			}
+			hub.dispatch(params)
+		})
+	}
+	c.locker.Unlock()
+	return hub.subscribe(handler)
+}
+
+// Enable 启用协议域(如 Network、Performance)，重复调用不会重复发送命令
+func (c *CDPSession) Enable(ctx context.Context, domain string) error {
+	c.locker.Lock()
+	enabled := c.enabled[domain]
+	c.locker.Unlock()
+	if enabled {
+		return nil
+	}
+	if _, err := c.Send(ctx, domain+".enable", nil); err != nil {
+		return err
+	}
+	c.locker.Lock()
+	c.enabled[domain] = true
+	c.locker.Unlock()
+	return nil
+}
+
+// Detach 断开会话，之后不能再发送命令或接收事件；标签页的 CDP() 会重新创建会话
+func (c *CDPSession) Detach() error {
+	if c.detach != nil {
+		c.detach(c)
+	}
+	if err := c.session.Detach(); err != nil {
+		return fmt.Errorf("无法断开 CDP 会话: %w", err)
+	}
+	return nil
+}
+
+func (c *CDPSession) Network() *CDPNetwork {
+	return &CDPNetwork{session: c}
+}
+
+func (c *CDPSession) Emulation() *CDPEmulation {
+	return &CDPEmulation{session: c}
+}
+
+func (c *CDPSession) Performance() *CDPPerformance {
+	return &CDPPerformance{session: c}
+}
+
+func (c *CDPSession) Input() *CDPInput {
+	return &CDPInput{session: c}
+}
+
+// NetworkConditions 网络条件，吞吐量单位为字节/秒，0 表示不限制
+type NetworkConditions struct {
+	Offline            bool
+	Latency            time.Duration // 额外延迟
+	DownloadThroughput float64
+	UploadThroughput   float64
+}
+
+// CDPNetwork Network 域命令
+type CDPNetwork struct {
+	session *CDPSession
+}
+
+// EmulateConditions 模拟网络条件
+func (n *CDPNetwork) EmulateConditions(ctx context.Context, conditions NetworkConditions) error {
+	if err := n.session.Enable(ctx, "Network"); err != nil {
+		return err
+	}
+	download, upload := conditions.DownloadThroughput, conditions.UploadThroughput
+	if download <= 0 {
+		download = -1
+	}
+	if upload <= 0 {
+		upload = -1
+	}
+	_, err := n.session.Send(ctx, "Network.emulateNetworkConditions", map[string]any{
+		"offline":            conditions.Offline,
+		"latency":            float64(conditions.Latency) / float64(time.Millisecond),
+		"downloadThroughput": download,
+		"uploadThroughput":   upload,
+	})
+	return err
+}
+
+// ClearConditions 取消网络条件模拟
+func (n *CDPNetwork) ClearConditions(ctx context.Context) error {
+	return n.EmulateConditions(ctx, NetworkConditions{})
+}
+
+// SetCacheDisabled 禁用或启用浏览器缓存
+func (n *CDPNetwork) SetCacheDisabled(ctx context.Context, disabled bool) error {
+	if err := n.session.Enable(ctx, "Network"); err != nil {
+		return err
+	}
+	_, err := n.session.Send(ctx, "Network.setCacheDisabled", map[string]any{"cacheDisabled": disabled})
+	return err
+}
+
+// SetExtraHeaders 为之后的所有请求附加请求头，传入空值清除
+func (n *CDPNetwork) SetExtraHeaders(ctx context.Context, headers map[string]string) error {
+	if err := n.session.Enable(ctx, "Network"); err != nil {
+		return err
+	}
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	_, err := n.session.Send(ctx, "Network.setExtraHTTPHeaders", map[string]any{"headers": headers})
+	return err
+}
+
+// ClearBrowserCache 清空浏览器缓存
+func (n *CDPNetwork) ClearBrowserCache(ctx context.Context) error {
+	_, err := n.session.Send(ctx, "Network.clearBrowserCache", nil)
+	return err
+}
+
+// DeviceMetrics 设备屏幕参数
+type DeviceMetrics struct {
+	Width             int
+	Height            int
+	DeviceScaleFactor float64 // 0 表示不覆盖
+	Mobile            bool
+}
+
+// CDPEmulation Emulation 域命令
+type CDPEmulation struct {
+	session *CDPSession
+}
+
+// SetCPUThrottlingRate 设置 CPU 降速倍数，1 表示不降速，4 表示慢 4 倍
+func (e *CDPEmulation) SetCPUThrottlingRate(ctx context.Context, rate float64) error {
+	if rate < 1 {
+		return fmt.Errorf("CPU 降速倍数不能小于 1: %v", rate)
+	}
+	_, err := e.session.Send(ctx, "Emulation.setCPUThrottlingRate", map[string]any{"rate": rate})
+	return err
+}
+
+// SetDeviceMetrics 覆盖屏幕尺寸和像素比
+func (e *CDPEmulation) SetDeviceMetrics(ctx context.Context, metrics DeviceMetrics) error {
+	_, err := e.session.Send(ctx, "Emulation.setDeviceMetricsOverride", map[string]any{
+		"width":             metrics.Width,
+		"height":            metrics.Height,
+		"deviceScaleFactor": metrics.DeviceScaleFactor,
+		"mobile":            metrics.Mobile,
+	})
+	return err
+}
+
+// ClearDeviceMetrics 取消屏幕参数覆盖
+func (e *CDPEmulation) ClearDeviceMetrics(ctx context.Context) error {
+	_, err := e.session.Send(ctx, "Emulation.clearDeviceMetricsOverride", nil)
+	return err
+}
+
+// SetTouchEmulation 开启或关闭触摸屏模拟
+func (e *CDPEmulation) SetTouchEmulation(ctx context.Context, enabled bool, maxTouchPoints int) error {
+	params := map[string]any{"enabled": enabled}
+	if maxTouchPoints > 0 {
+		params["maxTouchPoints"] = maxTouchPoints
+	}
+	_, err := e.session.Send(ctx, "Emulation.setTouchEmulationEnabled", params)
+	return err
+}
+
+// SetUserAgent 覆盖 User-Agent，acceptLanguage 可为空
+func (e *CDPEmulation) SetUserAgent(ctx context.Context, userAgent string, acceptLanguage string) error {
+	params := map[string]any{"userAgent": userAgent}
+	if acceptLanguage != "" {
+		params["acceptLanguage"] = acceptLanguage
+	}
+	_, err := e.session.Send(ctx, "Emulation.setUserAgentOverride", params)
+	return err
+}
+
+// SetTimezone 覆盖时区，如 Asia/Tokyo，为空时取消覆盖
+func (e *CDPEmulation) SetTimezone(ctx context.Context, timezoneID string) error {
+	_, err := e.session.Send(ctx, "Emulation.setTimezoneOverride", map[string]any{"timezoneId": timezoneID})
+	return err
+}
+
+// SetLocale 覆盖 Intl 和 navigator.language 使用的语言区域，如 ja-JP，为空时取消覆盖
+func (e *CDPEmulation) SetLocale(ctx context.Context, locale string) error {
+	params := map[string]any{}
+	if locale != "" {
+		params["locale"] = locale
+	}
+	_, err := e.session.Send(ctx, "Emulation.setLocaleOverride", params)
+	return err
+}
+
+// CDPPerformance Performance 域命令
+type CDPPerformance struct {
+	session *CDPSession
+}
+
+// Metrics 返回运行时性能指标，如 JSHeapUsedSize、Nodes、LayoutCount、TaskDuration
+func (p *CDPPerformance) Metrics(ctx context.Context) (map[string]float64, error) {
+	if err := p.session.Enable(ctx, "Performance"); err != nil {
+		return nil, err
+	}
+	var result struct {
+		Metrics []struct {
+			Name  string  `json:"name"`
+			Value float64 `json:"value"`
+		} `json:"metrics"`
+	}
+	if err := p.session.SendAs(ctx, "Performance.getMetrics", nil, &result); err != nil {
+		return nil, err
+	}
+	metrics := make(map[string]float64, len(result.Metrics))
+	for _, metric := range result.Metrics {
+		metrics[metric.Name] = metric.Value
+	}
+	return metrics, nil
+}
+
+// 输入事件的修饰键，可按位组合
+const (
+	ModifierAlt   = 1
+	ModifierCtrl  = 2
+	ModifierMeta  = 4
+	ModifierShift = 8
+)
+
+// MouseEvent 鼠标事件，Type 为 mousePressed、mouseReleased、mouseMoved、mouseWheel
+type MouseEvent struct {
+	Type       string
+	X          float64
+	Y          float64
+	Button     string // none、left、middle、right，默认 none
+	ClickCount int
+	DeltaX     float64 // 仅 mouseWheel
+	DeltaY     float64 // 仅 mouseWheel
+	Modifiers  int
+}
+
+// KeyEvent 键盘事件，Type 为 keyDown、keyUp、rawKeyDown、char
+type KeyEvent struct {
+	Type                  string
+	Key                   string // 如 Enter、a
+	Code                  string // 如 Enter、KeyA
+	Text                  string // 输入的字符
+	WindowsVirtualKeyCode int
+	Modifiers             int
+}
+
+// CDPInput Input 域命令，直接向浏览器派发原始输入事件
+type CDPInput struct {
+	session *CDPSession
+}
+
+func (i *CDPInput) DispatchMouse(ctx context.Context, event MouseEvent) error {
+	if event.Button == "" {
+		event.Button = "none"
+	}
+	params := map[string]any{
+		"type":       event.Type,
+		"x":          event.X,
+		"y":          event.Y,
+		"button":     event.Button,
+		"clickCount": event.ClickCount,
+		"modifiers":  event.Modifiers,
+	}
+	if event.Type == "mouseWheel" {
+		params["deltaX"] = event.DeltaX
+		params["deltaY"] = event.DeltaY
+	}
+	_, err := i.session.Send(ctx, "Input.dispatchMouseEvent", params)
+	return err
+}
+
+func (i *CDPInput) DispatchKey(ctx context.Context, event KeyEvent) error {
+	params := map[string]any{
+		"type":      event.Type,
+		"modifiers": event.Modifiers,
+	}
+	if event.Key != "" {
+		params["key"] = event.Key
+	}
+	if event.Code != "" {
+		params["code"] = event.Code
+	}
+	if event.Text != "" {
+		params["text"] = event.Text
+	}
+	if event.WindowsVirtualKeyCode > 0 {
+		params["windowsVirtualKeyCode"] = event.WindowsVirtualKeyCode
+	}
+	_, err := i.session.Send(ctx, "Input.dispatchKeyEvent", params)
+	return err
+}
+
+// InsertText 像输入法一样直接插入文本，不产生按键事件
+func (i *CDPInput) InsertText(ctx context.Context, text string) error {
+	_, err := i.session.Send(ctx, "Input.insertText", map[string]any{"text": text})
+	return err
+}
@@ -0,0 +1,574 @@
+package handle
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// anchorLinksScript 读取页面中所有链接的绝对地址，忽略 rel="nofollow"
+const anchorLinksScript = `() => Array.from(document.querySelectorAll("a[href]"))
+	.filter(a => !(a.rel || "").toLowerCase().split(/\s+/).includes("nofollow"))
+	.map(a => a.href)`
+
+// CrawlRequest 待抓取的地址
+type CrawlRequest struct {
+	URL     string // 规范化后的地址
+	Depth   int    // 链接深度，种子为 0
+	Referer string // 发现该地址的页面，种子为空
+}
+
+// CrawlPage 抓取成功的页面，处理函数中可以通过 Tab 继续操作页面
+type CrawlPage struct {
+	Request CrawlRequest
+	Tab     TabPage
+	Result  *NavigationResult
+}
+
+// LinkExtractor 从页面中提取待抓取的链接，相对地址按页面最终地址解析
+type LinkExtractor func(ctx context.Context, page *CrawlPage) ([]string, error)
+
+// CrawlHandler 页面处理函数，返回错误时不再提取该页面的链接
+type CrawlHandler func(ctx context.Context, page *CrawlPage) error
+
+// AnchorLinks 默认的链接提取器，返回页面中所有 a 标签的地址，忽略 rel="nofollow"
+func AnchorLinks(ctx context.Context, page *CrawlPage) ([]string, error) {
+	return EvaluateAs[[]string](ctx, page.Tab, anchorLinksScript)
+}
+
+// CrawlOptions 爬虫选项
+type CrawlOptions struct {
+	Seeds         []string                          // 起始地址
+	MaxDepth      int                               // 最大链接深度，0 表示不限
+	MaxPages      int                               // 最多打开的页面数，被 robots.txt 禁止的地址不计入，0 表示不限
+	SameDomain    bool                              // 只跟随与任一种子同域名的链接，域名规则同 TabPage.Domain，IP 和单段主机名按主机名比较
+	Filter        func(u *url.URL) bool             // 返回 false 的地址不加入队列，可为空
+	Concurrency   int                               // 同时使用的标签页数，默认 2
+	PerDomain     int                               // 同一域名的最大并发数，默认 1，域名规则同 SameDomain
+	DomainDelay   time.Duration                     // 同一域名上一次抓取完成到下一次开始的最小间隔，robots.txt 的 Crawl-delay 更大时使用后者
+	RespectRobots bool                              // 遵守 robots.txt
+	UserAgent     string                            // 匹配 robots.txt 分组的名称，为空时只使用 * 分组
+	Navigate      NavigateOptions                   // 打开页面的选项，未指定 Referer 时使用发现该地址的页面
+	PageTimeout   time.Duration                     // 单个页面的导航和处理超时，默认 60s
+	Links         LinkExtractor                     // 链接提取器，默认 AnchorLinks
+	Handler       CrawlHandler                      // 页面处理函数，可为空
+	OnError       func(req CrawlRequest, err error) // 页面抓取或处理失败时回调，可为空
+}
+
+// CrawlStats 抓取统计
+type CrawlStats struct {
+	Visited    int // 成功打开的页面数
+	Failed     int // 打开或处理失败的页面数
+	Disallowed int // 被 robots.txt 禁止的页面数
+	Queued     int // 加入队列的地址数，含种子
+}
+
+// Crawler 从种子地址开始按广度优先抓取页面，每个并发使用一个独立的标签页
+type Crawler struct {
+	session  Session
+	opts     CrawlOptions
+	domains  map[string]bool
+	frontier *crawlFrontier
+	robots   *robotsCache
+	mu       sync.Mutex
+	stats    CrawlStats
+}
+
+// NewCrawler 创建爬虫，标签页在 session 中打开
+func NewCrawler(session Session, opts CrawlOptions) (*Crawler, error) {
+	if session == nil {
+		return nil, fmt.Errorf("爬虫缺少会话")
+	}
+	if len(opts.Seeds) == 0 {
+		return nil, fmt.Errorf("爬虫缺少种子地址")
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 2
+	}
+	if opts.PerDomain <= 0 {
+		opts.PerDomain = 1
+	}
+	if opts.PageTimeout <= 0 {
+		opts.PageTimeout = 60 * time.Second
+	}
+	if opts.Links == nil {
+		opts.Links = AnchorLinks
+	}
+
+	c := &Crawler{
+		session:  session,
+		opts:     opts,
+		domains:  make(map[string]bool),
+		frontier: new_crawl_frontier(opts.MaxPages, opts.PerDomain, opts.DomainDelay),
+		robots:   &robotsCache{userAgent: opts.UserAgent, entries: make(map[string]*robotsEntry)},
+	}
+	for _, seed := range opts.Seeds {
+		u, err := normalize_url(seed, nil)
+		if err != nil {
+			return nil, fmt.Errorf("无效的种子地址 %s: %w", seed, err)
+		}
+		domain := crawl_domain(u)
+		c.domains[domain] = true
+		if c.frontier.push(CrawlRequest{URL: u.String()}, domain) {
+			c.stats.Queued++
+		}
+	}
+	return c, nil
+}
+
+// Run 开始抓取，队列为空、达到 MaxPages 或 ctx 结束时返回；单个页面的失败只计入统计
+func (c *Crawler) Run(ctx context.Context) (CrawlStats, error) {
+	tabs := make([]TabPage, 0, c.opts.Concurrency)
+	for i := 0; i < c.opts.Concurrency; i++ {
+		tab := c.session.NewTabPage(fmt.Sprintf("crawl-%s-%d", c.session.ID(), i), "about:blank")
+		if tab == nil {
+			for _, opened := range tabs {
+				opened.Close()
+			}
+			return c.Stats(), fmt.Errorf("爬虫无法创建第 %d 个标签页", i+1)
+		}
+		tabs = append(tabs, tab)
+	}
+
+	var wg sync.WaitGroup
+	for _, tab := range tabs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer tab.Close()
+			c.work(ctx, tab)
+		}()
+	}
+	wg.Wait()
+	return c.Stats(), ctx.Err()
+}
+
+// Stats 当前的抓取统计
+func (c *Crawler) Stats() CrawlStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+func (c *Crawler) work(ctx context.Context, tab TabPage) {
+	for {
+		req, domain, ok := c.frontier.next(ctx)
+		if !ok {
+			return
+		}
+		navigated := c.visit(ctx, tab, req, domain)
+		c.frontier.done(domain, navigated)
+	}
+}
+
+// visit 抓取一个页面，返回是否实际打开了页面
+func (c *Crawler) visit(ctx context.Context, tab TabPage, req CrawlRequest, domain string) bool {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		c.fail(req, err)
+		return false
+	}
+	if c.opts.RespectRobots {
+		rules, wait, err := c.robots.get(ctx, tab, u)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("暂缓抓取 %s: %v", req.URL, err)
+				c.frontier.retry(req, domain, wait)
+			}
+			return false
+		}
+		if rules != nil && rules.CrawlDelay > 0 {
+			c.frontier.setDelay(domain, rules.CrawlDelay)
+		}
+		if !rules.Allowed(u.RequestURI()) {
+			c.count(func(s *CrawlStats) { s.Disallowed++ })
+			return false
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.opts.PageTimeout)
+	defer cancel()
+
+	opts := c.opts.Navigate
+	if opts.Referer == "" {
+		opts.Referer = req.Referer
+	}
+	result, err := tab.Navigate(ctx, req.URL, opts)
+	if err != nil {
+		c.fail(req, err)
+		return true
+	}
+	if !result.OK() {
+		c.fail(req, fmt.Errorf("页面 %s 返回状态码 %d", req.URL, result.Status))
+		return true
+	}
+	if final, err := normalize_url(result.URL, nil); err == nil {
+		c.frontier.markSeen(final.String())
+	}
+
+	page := &CrawlPage{Request: req, Tab: tab, Result: result}
+	if c.opts.Handler != nil {
+		if err := c.opts.Handler(ctx, page); err != nil {
+			c.fail(req, fmt.Errorf("处理页面 %s 失败: %w", req.URL, err))
+			return true
+		}
+	}
+	c.count(func(s *CrawlStats) { s.Visited++ })
+
+	if c.opts.MaxDepth > 0 && req.Depth >= c.opts.MaxDepth {
+		return true
+	}
+	links, err := c.opts.Links(ctx, page)
+	if err != nil {
+		log.Printf("提取页面 %s 的链接失败: %v", req.URL, err)
+		return true
+	}
+	base, _ := url.Parse(result.URL)
+	for _, link := range links {
+		c.enqueue(link, base, req)
+	}
+	return true
+}
+
+// enqueue 规范化链接并按范围和过滤条件加入队列
+func (c *Crawler) enqueue(link string, base *url.URL, from CrawlRequest) {
+	u, err := normalize_url(link, base)
+	if err != nil {
+		return
+	}
+	domain := crawl_domain(u)
+	if c.opts.SameDomain && !c.domains[domain] {
+		return
+	}
+	if c.opts.Filter != nil && !c.opts.Filter(u) {
+		return
+	}
+	if c.frontier.push(CrawlRequest{URL: u.String(), Depth: from.Depth + 1, Referer: from.URL}, domain) {
+		c.count(func(s *CrawlStats) { s.Queued++ })
+	}
+}
+
+func (c *Crawler) fail(req CrawlRequest, err error) {
+	c.count(func(s *CrawlStats) { s.Failed++ })
+	if c.opts.OnError != nil {
+		c.opts.OnError(req, err)
+	} else {
+		log.Printf("抓取页面 %s 失败: %v", req.URL, err)
+	}
+}
+
+func (c *Crawler) count(update func(s *CrawlStats)) {
+	c.mu.Lock()
+	update(&c.stats)
+	c.mu.Unlock()
+}
+
+// normalize_url 按 base 解析地址并规范化: 只接受 http、https，协议和主机名小写，
+// 去掉默认端口和片段，空路径补为 /，查询参数按名称排序
+func normalize_url(raw string, base *url.URL) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, err
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("不支持的协议: %s", u.Scheme)
+	}
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if host == "" {
+		return nil, fmt.Errorf("地址缺少主机名: %s", raw)
+	}
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	u.Host = host
+	if port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	}
+	u.User = nil
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = u.Query().Encode()
+	}
+	return u, nil
+}
+
+// crawl_domain 地址所属的域名，与 TabPage.Domain 一致；IP 和单段主机名直接使用主机名
+func crawl_domain(u *url.URL) string {
+	host := u.Hostname()
+	if net.ParseIP(host) != nil || !strings.Contains(host, ".") {
+		return host
+	}
+	domain, err := extract_domain_from_url(u.String())
+	if err != nil {
+		return host
+	}
+	return domain
+}
+
+// crawlFrontier 待抓取队列，按加入顺序分发，跳过并发已满或仍在间隔期内的域名
+type crawlFrontier struct {
+	mu        sync.Mutex
+	queue     []crawlEntry
+	seen      map[string]struct{}
+	domains   map[string]*crawlDomain
+	inflight  int
+	started   int // 已分发且未确认跳过的数量，用于 MaxPages
+	maxPages  int
+	perDomain int
+	delay     time.Duration
+	changed   chan struct{} // 队列或域名状态变化时关闭并替换
+}
+
+type crawlEntry struct {
+	req    CrawlRequest
+	domain string
+}
+
+type crawlDomain struct {
+	active int
+	next   time.Time
+	delay  time.Duration
+}
+
+func new_crawl_frontier(maxPages int, perDomain int, delay time.Duration) *crawlFrontier {
+	return &crawlFrontier{
+		seen:      make(map[string]struct{}),
+		domains:   make(map[string]*crawlDomain),
+		maxPages:  maxPages,
+		perDomain: perDomain,
+		delay:     delay,
+		changed:   make(chan struct{}),
+	}
+}
+
+// notify 唤醒等待中的 next，调用时需持有锁
+func (f *crawlFrontier) notify() {
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (f *crawlFrontier) domain(name string) *crawlDomain {
+	d, ok := f.domains[name]
+	if !ok {
+		d = &crawlDomain{delay: f.delay}
+		f.domains[name] = d
+	}
+	return d
+}
+
+// push 加入未见过的地址，返回是否加入
+func (f *crawlFrontier) push(req CrawlRequest, domain string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.seen[req.URL]; ok {
+		return false
+	}
+	f.seen[req.URL] = struct{}{}
+	f.queue = append(f.queue, crawlEntry{req: req, domain: domain})
+	f.notify()
+	return true
+}
+
+// markSeen 记录重定向后的地址，避免重复抓取
+func (f *crawlFrontier) markSeen(u string) {
+	f.mu.Lock()
+	f.seen[u] = struct{}{}
+	f.mu.Unlock()
+}
+
+// next 取出下一个可抓取的地址，队列为空且没有进行中的抓取、达到 MaxPages 或 ctx 结束时返回 false；
+// 达到 MaxPages 但仍有进行中的抓取时等待，它们可能因 robots.txt 未打开页面而释放名额
+func (f *crawlFrontier) next(ctx context.Context) (CrawlRequest, string, bool) {
+	for {
+		f.mu.Lock()
+		full := f.maxPages > 0 && f.started >= f.maxPages
+		if f.inflight == 0 && (full || len(f.queue) == 0) {
+			f.mu.Unlock()
+			return CrawlRequest{}, "", false
+		}
+		now := time.Now()
+		wait := time.Duration(-1)
+		for i, entry := range f.queue {
+			if full {
+				break
+			}
+			d := f.domain(entry.domain)
+			if d.active >= f.perDomain {
+				continue
+			}
+			if remaining := d.next.Sub(now); remaining > 0 {
+				if wait < 0 || remaining < wait {
+					wait = remaining
+				}
+				continue
+			}
+			f.queue = append(f.queue[:i], f.queue[i+1:]...)
+			d.active++
+			f.inflight++
+			f.started++
+			f.mu.Unlock()
+			return entry.req, entry.domain, true
+		}
+		changed := f.changed
+		f.mu.Unlock()
+
+		var timer *time.Timer
+		var fired <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			fired = timer.C
+		}
+		select {
+		case <-ctx.Done():
+		case <-changed:
+		case <-fired:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return CrawlRequest{}, "", false
+		}
+	}
+}
+
+// done 一次分发结束；navigated 为 true 时该域名从现在开始计算间隔，为 false 时不计入 MaxPages
+func (f *crawlFrontier) done(domain string, navigated bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	d := f.domain(domain)
+	d.active--
+	f.inflight--
+	if navigated {
+		d.next = time.Now().Add(d.delay)
+	} else {
+		f.started--
+	}
+	f.notify()
+}
+
+// retry 将已取出的地址放回队列，该域名在 delay 之后才会再次分发
+func (f *crawlFrontier) retry(req CrawlRequest, domain string, delay time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	d := f.domain(domain)
+	if next := time.Now().Add(delay); next.After(d.next) {
+		d.next = next
+	}
+	f.queue = append(f.queue, crawlEntry{req: req, domain: domain})
+}
+
+// setDelay 将域名的抓取间隔提高到至少 delay
+func (f *crawlFrontier) setDelay(domain string, delay time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	d := f.domain(domain)
+	d.delay = max(d.delay, delay)
+}
+
+// robots.txt 获取失败时的处理: 失败不缓存，按 robotsRetryDelay 翻倍退避后重新获取，
+// 连续失败 robotsMaxAttempts 次后视为全部禁止
+const (
+	robotsTimeout     = 30 * time.Second
+	robotsRetryDelay  = 5 * time.Second
+	robotsMaxAttempts = 3
+)
+
+// robotsCache 按 origin 缓存获取成功的 robots.txt 规则
+type robotsCache struct {
+	userAgent string
+	mu        sync.Mutex
+	entries   map[string]*robotsEntry
+}
+
+type robotsEntry struct {
+	mu       sync.Mutex // 同一 origin 同时只请求一次
+	fetched  bool
+	rules    *RobotsRules
+	failures int
+	retryAt  time.Time
+}
+
+// get 返回 origin 的规则；获取失败或仍在退避期内时返回错误和建议的等待时间，
+// ctx 应为整个抓取的 ctx，请求本身另有 robotsTimeout 超时
+func (r *robotsCache) get(ctx context.Context, tab TabPage, u *url.URL) (*RobotsRules, time.Duration, error) {
+	origin := u.Scheme + "://" + u.Host
+	r.mu.Lock()
+	entry, ok := r.entries[origin]
+	if !ok {
+		entry = &robotsEntry{}
+		r.entries[origin] = entry
+	}
+	r.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.fetched {
+		return entry.rules, 0, nil
+	}
+	if wait := time.Until(entry.retryAt); wait > 0 {
+		return nil, wait, fmt.Errorf("%s 的 robots.txt 暂不可用，%s 后重试", origin, wait.Round(time.Second))
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, robotsTimeout)
+	defer cancel()
+	rules, err := fetch_robots(fetchCtx, tab, origin, r.userAgent)
+	if err == nil {
+		entry.fetched, entry.rules = true, rules
+		return rules, 0, nil
+	}
+	if ctx.Err() != nil {
+		return nil, 0, ctx.Err()
+	}
+
+	entry.failures++
+	if entry.failures >= robotsMaxAttempts {
+		log.Printf("%s 的 robots.txt 连续 %d 次获取失败，不再抓取该站点: %v", origin, entry.failures, err)
+		entry.fetched = true
+		entry.rules = &RobotsRules{rules: []robotsRule{new_robots_rule(false, "/")}}
+		return entry.rules, 0, nil
+	}
+	wait := robotsRetryDelay << (entry.failures - 1)
+	entry.retryAt = time.Now().Add(wait)
+	return nil, wait, err
+}
+
+// fetch_robots 通过标签页所在上下文请求 robots.txt，与页面共用代理和 Cookie；
+// 4xx 视为不限制，返回 nil 规则；5xx 或无法访问时返回错误
+func fetch_robots(ctx context.Context, tab TabPage, origin string, userAgent string) (*RobotsRules, error) {
+	response, err := tab.Page().Context().Request().Get(origin+"/robots.txt", playwright.APIRequestContextGetOptions{
+		Timeout: ctx_timeout(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("无法获取 %s 的 robots.txt: %w", origin, err)
+	}
+	defer response.Dispose()
+
+	status := response.Status()
+	switch {
+	case status >= 500:
+		return nil, fmt.Errorf("%s 的 robots.txt 返回状态码 %d", origin, status)
+	case status >= 400:
+		return nil, nil
+	}
+	body, err := response.Body()
+	if err != nil {
+		return nil, fmt.Errorf("无法读取 %s 的 robots.txt: %w", origin, err)
+	}
+	return ParseRobots(body, userAgent), nil
+}
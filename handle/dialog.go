@@ -0,0 +1,152 @@
+package handle
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// maxDialogRecords 每个标签页和浏览器最多保留的对话框记录数
+const maxDialogRecords = 200
+
+// DialogAction 对话框的处理方式
+type DialogAction string
+
+const (
+	DialogAccept  DialogAction = "accept"  // 确认
+	DialogDismiss DialogAction = "dismiss" // 取消
+)
+
+// DialogInfo 页面弹出的对话框
+type DialogInfo struct {
+	TabID        string // 标签页ID
+	Type         string // alert、confirm、prompt、beforeunload
+	Message      string // 对话框内容
+	DefaultValue string // prompt 的默认值
+	URL          string // 弹出对话框时的页面地址
+}
+
+// DialogRecord 对话框及其处理结果
+type DialogRecord struct {
+	DialogInfo
+	Action     DialogAction
+	PromptText string
+	Time       time.Time
+}
+
+// DialogPolicy 对话框处理策略，Handler 不为空时优先使用 Handler 的返回值
+type DialogPolicy struct {
+	Action     DialogAction
+	PromptText string // 确认 prompt 时填入的内容，为空时使用默认值
+	Handler    func(info DialogInfo) (action DialogAction, promptText string)
+}
+
+// AcceptDialogs 确认所有对话框
+func AcceptDialogs() DialogPolicy {
+	return DialogPolicy{Action: DialogAccept}
+}
+
+// DismissDialogs 取消所有对话框
+func DismissDialogs() DialogPolicy {
+	return DialogPolicy{Action: DialogDismiss}
+}
+
+// AnswerPrompts 确认所有对话框，prompt 填入 text
+func AnswerPrompts(text string) DialogPolicy {
+	return DialogPolicy{Action: DialogAccept, PromptText: text}
+}
+
+// DialogCallback 由回调决定每个对话框的处理方式
+func DialogCallback(handler func(info DialogInfo) (DialogAction, string)) DialogPolicy {
+	return DialogPolicy{Handler: handler}
+}
+
+// dialogState 保存对话框策略和处理记录
+type dialogState struct {
+	locker  sync.Mutex
+	policy  *DialogPolicy
+	records []DialogRecord
+}
+
+func (d *dialogState) setPolicy(policy *DialogPolicy) {
+	d.locker.Lock()
+	defer d.locker.Unlock()
+	d.policy = policy
+}
+
+func (d *dialogState) getPolicy() *DialogPolicy {
+	d.locker.Lock()
+	defer d.locker.Unlock()
+	return d.policy
+}
+
+func (d *dialogState) append(record DialogRecord) {
+	d.locker.Lock()
+	defer d.locker.Unlock()
+	d.records = append(d.records, record)
+	if len(d.records) > maxDialogRecords {
+		d.records = d.records[len(d.records)-maxDialogRecords:]
+	}
+}
+
+func (d *dialogState) list() []DialogRecord {
+	d.locker.Lock()
+	defer d.locker.Unlock()
+	return append([]DialogRecord(nil), d.records...)
+}
+
+// handle_dialog 按标签页策略处理对话框，未设置时使用浏览器策略，二者都未设置时取消对话框(beforeunload 除外)
+func handle_dialog(tabID string, dialog playwright.Dialog, tab *dialogState, browser *dialogState) {
+	info := DialogInfo{
+		TabID:        tabID,
+		Type:         dialog.Type(),
+		Message:      dialog.Message(),
+		DefaultValue: dialog.DefaultValue(),
+	}
+	if page := dialog.Page(); page != nil {
+		info.URL = page.URL()
+	}
+
+	policy := tab.getPolicy()
+	if policy == nil {
+		policy = browser.getPolicy()
+	}
+	action, promptText := DialogDismiss, ""
+	if info.Type == "beforeunload" {
+		action = DialogAccept
+	}
+	if policy != nil {
+		action, promptText = policy.Action, policy.PromptText
+		if policy.Handler != nil {
+			action, promptText = policy.Handler(info)
+		}
+	}
+	if promptText == "" {
+		promptText = info.DefaultValue
+	}
+
+	var err error
+	if action == DialogAccept {
+		if info.Type == "prompt" {
+			err = dialog.Accept(promptText)
+		} else {
+			err = dialog.Accept()
+		}
+	} else {
+		action = DialogDismiss
+		err = dialog.Dismiss()
+	}
+	if err != nil {
+		log.Printf("处理对话框失败: %v", err)
+	}
+	log.Printf("已处理对话框[%s] %s: %s", info.Type, action, info.Message)
+
+	record := DialogRecord{DialogInfo: info, Action: action, Time: time.Now()}
+	if info.Type == "prompt" && action == DialogAccept {
+		record.PromptText = promptText
+	}
+	tab.append(record)
+	browser.append(record)
+}
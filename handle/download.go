@@ -0,0 +1,309 @@
+package handle
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// DownloadCollision 目标文件已存在时的处理策略
+type DownloadCollision string
+
+const (
+	DownloadRename    DownloadCollision = "rename"    // 自动改名为 name (1).ext
+	DownloadOverwrite DownloadCollision = "overwrite" // 覆盖已有文件
+	DownloadFail      DownloadCollision = "fail"      // 放弃保存并报错
+)
+
+// DownloadState 下载事件类型
+type DownloadState string
+
+const (
+	DownloadStarted   DownloadState = "started"
+	DownloadProgress  DownloadState = "progress"
+	DownloadCompleted DownloadState = "completed"
+	DownloadFailed    DownloadState = "failed"
+	DownloadCanceled  DownloadState = "canceled"
+)
+
+// DownloadOptions 下载管理配置
+type DownloadOptions struct {
+	Dir              string            // 保存目录，默认 系统临时目录/browser-downloads
+	Collision        DownloadCollision // 文件名冲突策略，默认 DownloadRename
+	MaxSavedSize     int64             // 保存文件的大小上限(字节)，0 表示不限制；Playwright 不提供传输中的大小，下载完成后才校验，超限则删除不保存
+	Timeout          time.Duration     // 单个下载的最长耗时，0 表示不限制；超时取消下载
+	ProgressInterval time.Duration     // 下载中进度事件的上报间隔，默认 1 秒
+}
+
+// DownloadEvent 下载事件
+type DownloadEvent struct {
+	ID                string        // 下载序号
+	TabID             string        // 触发下载的标签页ID
+	URL               string        // 下载地址
+	SuggestedFilename string        // 网站建议的文件名
+	State             DownloadState // 事件类型
+	Path              string        // 保存路径，仅完成时有值
+	Size              int64         // 文件大小，仅完成时有值
+	Elapsed           time.Duration // 已耗时
+	Err               error         // 失败或取消的原因
+}
+
+// DownloadResult 下载完成的结果
+type DownloadResult struct {
+	Path              string
+	SuggestedFilename string
+	URL               string
+	Size              int64
+	Elapsed           time.Duration
+}
+
+// DownloadManager 接管会话内所有标签页的下载，按配置保存到下载目录并分发下载事件。
+// Playwright 不提供下载字节进度，进度事件只包含已耗时。
+type DownloadManager struct {
+	locker  sync.Mutex
+	options DownloadOptions
+	seq     atomic.Int64
+	events  *eventHub[DownloadEvent]
+}
+
+func newDownloadManager() *DownloadManager {
+	m := &DownloadManager{events: newEventHub[DownloadEvent]()}
+	m.SetOptions(DownloadOptions{})
+	return m
+}
+
+// SetOptions 更新下载配置，对之后开始的下载生效
+func (m *DownloadManager) SetOptions(opts DownloadOptions) {
+	if opts.Dir == "" {
+		opts.Dir = filepath.Join(os.TempDir(), "browser-downloads")
+	}
+	if opts.Collision == "" {
+		opts.Collision = DownloadRename
+	}
+	if opts.ProgressInterval <= 0 {
+		opts.ProgressInterval = time.Second
+	}
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	m.options = opts
+}
+
+// Options 返回当前下载配置
+func (m *DownloadManager) Options() DownloadOptions {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	return m.options
+}
+
+// OnEvent 订阅下载事件，返回取消订阅的函数
+func (m *DownloadManager) OnEvent(fn func(event DownloadEvent)) func() {
+	return m.events.subscribe(fn)
+}
+
+// handle 在事件回调中调用，保存过程放到独立协程中执行
+func (m *DownloadManager) handle(tabID string, download playwright.Download) {
+	event := DownloadEvent{
+		ID:                fmt.Sprintf("%d", m.seq.Add(1)),
+		TabID:             tabID,
+		URL:               download.URL(),
+		SuggestedFilename: download.SuggestedFilename(),
+	}
+	go m.save(event, download, m.Options())
+}
+
+func (m *DownloadManager) save(event DownloadEvent, download playwright.Download, opts DownloadOptions) {
+	started := time.Now()
+	emit := func(state DownloadState, err error) {
+		event.State = state
+		event.Err = err
+		event.Elapsed = time.Since(started)
+		m.events.dispatch(event)
+	}
+	emit(DownloadStarted, nil)
+
+	type pathResult struct {
+		path string
+		err  error
+	}
+	done := make(chan pathResult, 1)
+	go func() {
+		path, err := download.Path()
+		done <- pathResult{path, err}
+	}()
+
+	var timeout <-chan time.Time
+	if opts.Timeout > 0 {
+		timer := time.NewTimer(opts.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	ticker := time.NewTicker(opts.ProgressInterval)
+	defer ticker.Stop()
+
+	var result pathResult
+wait:
+	for {
+		select {
+		case result = <-done:
+			break wait
+		case <-ticker.C:
+			emit(DownloadProgress, nil)
+		case <-timeout:
+			if err := download.Cancel(); err != nil {
+				log.Printf("取消下载失败: %v", err)
+			}
+			emit(DownloadCanceled, fmt.Errorf("下载超时: %v", opts.Timeout))
+			return
+		}
+	}
+
+	if result.err != nil {
+		emit(DownloadFailed, fmt.Errorf("下载失败: %w", result.err))
+		return
+	}
+	if failure := download.Failure(); failure != nil {
+		emit(DownloadFailed, fmt.Errorf("下载失败: %w", failure))
+		return
+	}
+	info, err := os.Stat(result.path)
+	if err != nil {
+		emit(DownloadFailed, fmt.Errorf("无法读取下载文件: %w", err))
+		return
+	}
+	if opts.MaxSavedSize > 0 && info.Size() > opts.MaxSavedSize {
+		download.Delete()
+		emit(DownloadFailed, fmt.Errorf("下载文件超过大小限制: %d > %d", info.Size(), opts.MaxSavedSize))
+		return
+	}
+
+	target, err := resolve_download_path(opts.Dir, event.SuggestedFilename, opts.Collision)
+	if err != nil {
+		download.Delete()
+		emit(DownloadFailed, err)
+		return
+	}
+	if err := download.SaveAs(target); err != nil {
+		if opts.Collision != DownloadOverwrite {
+			os.Remove(target)
+		}
+		emit(DownloadFailed, fmt.Errorf("无法保存下载文件: %w", err))
+		return
+	}
+	download.Delete()
+
+	event.Path = target
+	event.Size = info.Size()
+	log.Printf("下载完成: %s -> %s", event.URL, target)
+	emit(DownloadCompleted, nil)
+}
+
+// expect 执行 action 并等待 tabID 触发的下一个下载完成
+func (m *DownloadManager) expect(ctx context.Context, tabID string, action func() error) (*DownloadResult, error) {
+	var (
+		id     string
+		events = make(chan DownloadEvent, 1)
+		locker sync.Mutex
+	)
+	unsubscribe := m.events.subscribe(func(event DownloadEvent) {
+		if event.TabID != tabID {
+			return
+		}
+		locker.Lock()
+		defer locker.Unlock()
+		if id == "" && event.State == DownloadStarted {
+			id = event.ID
+		}
+		if event.ID != id {
+			return
+		}
+		switch event.State {
+		case DownloadCompleted, DownloadFailed, DownloadCanceled:
+			select {
+			case events <- event:
+			default:
+			}
+		}
+	})
+	defer unsubscribe()
+
+	if action != nil {
+		if err := action(); err != nil {
+			return nil, fmt.Errorf("执行操作失败: %w", err)
+		}
+	}
+
+	select {
+	case event := <-events:
+		if event.State != DownloadCompleted {
+			return nil, event.Err
+		}
+		return &DownloadResult{
+			Path:              event.Path,
+			SuggestedFilename: event.SuggestedFilename,
+			URL:               event.URL,
+			Size:              event.Size,
+			Elapsed:           event.Elapsed,
+		}, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("等待下载超时: %w", ctx.Err())
+	}
+}
+
+// resolve_download_path 按冲突策略确定最终保存路径；除覆盖策略外会以 O_EXCL 创建空文件占用该路径，
+// 避免同名的并发下载选中同一个文件
+func resolve_download_path(dir, filename string, collision DownloadCollision) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("无法创建下载目录: %w", err)
+	}
+	filename = filepath.Base(filepath.Clean("/" + filename))
+	if filename == string(filepath.Separator) || filename == "." {
+		filename = "download"
+	}
+	target := filepath.Join(dir, filename)
+	if collision == DownloadOverwrite {
+		return target, nil
+	}
+	reserved, err := reserve_download_path(target)
+	if err != nil {
+		return "", err
+	}
+	if reserved {
+		return target, nil
+	}
+	if collision == DownloadFail {
+		return "", fmt.Errorf("下载文件已存在: %s", target)
+	}
+
+	ext := filepath.Ext(filename)
+	name := strings.TrimSuffix(filename, ext)
+	for i := 1; ; i++ {
+		candidate := filepath.Join(dir, fmt.Sprintf("%s (%d)%s", name, i, ext))
+		reserved, err := reserve_download_path(candidate)
+		if err != nil {
+			return "", err
+		}
+		if reserved {
+			return candidate, nil
+		}
+	}
+}
+
+// reserve_download_path 创建空文件占用路径，文件已存在时返回 false
+func reserve_download_path(path string) (bool, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("无法创建下载文件: %w", err)
+	}
+	return true, file.Close()
+}
@@ -1,10 +1,12 @@
 package handle
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os/exec"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"slices"
@@ -17,18 +19,29 @@ type EdgeBrowser struct {
 	port     int
 	browser  playwright.Browser
 	context  playwright.BrowserContext
+	session  *EdgeSession   // 默认会话
+	sessions []*EdgeSession // 全部会话，包含默认会话
 	tabPages []*EdgeTabPage
 	locker   sync.Mutex
+	dialogs  dialogState                 // 浏览器级对话框策略和记录
+	proxy    *ProxyConfig                // 启动时设置的浏览器级代理
+	proxies  ProxyProvider               // 为新会话分配代理
+	retry    atomic.Pointer[RetryPolicy] // 浏览器级重试策略，为空时不重试
 }
 
 // startNewEdge 启动新的 Edge 实例
-func startNewEdge(edgePath, port string) (*exec.Cmd, error) {
-	cmd := exec.Command(edgePath,
+func startNewEdge(edgePath, port string, opts LaunchOptions) (*exec.Cmd, error) {
+	args := []string{
 		"--new-window",
 		"about:blank",
 		"--remote-debugging-address=127.0.0.1",
-		"--remote-debugging-port="+port,
-		"--remote-allow-origins=http://127.0.0.1:"+port)
+		"--remote-debugging-port=" + port,
+		"--remote-allow-origins=http://127.0.0.1:" + port,
+	}
+	if opts.Proxy != nil {
+		args = append(args, opts.Proxy.launchArgs()...)
+	}
+	cmd := exec.Command(edgePath, args...)
 	log.Printf("启动 Edge 浏览器: %s", cmd.String())
 	err := cmd.Start()
 	if err != nil {
@@ -38,7 +51,7 @@ func startNewEdge(edgePath, port string) (*exec.Cmd, error) {
 	return cmd, nil
 }
 
-func newEdgeBrowser(edgePath string, debugPort int) (*EdgeBrowser, error) {
+func newEdgeBrowser(edgePath string, debugPort int, opts LaunchOptions) (*EdgeBrowser, error) {
 	// 1. 自动安装 Playwright 驱动
 	if err := playwright.Install(); err != nil {
 		return nil, err
@@ -61,7 +74,7 @@ func newEdgeBrowser(edgePath string, debugPort int) (*EdgeBrowser, error) {
 		}
 
 		// 启动新的 Edge 实例
-		_, err = startNewEdge(edgePath, fmt.Sprintf("%d", debugPort))
+		_, err = startNewEdge(edgePath, fmt.Sprintf("%d", debugPort), opts)
 		if err != nil {
 			pw.Stop()
 			return nil, fmt.Errorf("无法启动 Edge 浏览器: %v", err)
@@ -79,6 +92,10 @@ func newEdgeBrowser(edgePath string, debugPort int) (*EdgeBrowser, error) {
 		}
 	} else {
 		log.Printf("已找到正在运行的 Edge 实例，调试端口:%d\n", debugPort)
+		if opts.Proxy != nil {
+			log.Printf("已连接到运行中的 Edge 实例，启动代理设置不生效")
+			opts.Proxy = nil
+		}
 	}
 
 	// 4. 获取浏览器上下文并关闭所有默认页面
@@ -105,7 +122,11 @@ func newEdgeBrowser(edgePath string, debugPort int) (*EdgeBrowser, error) {
 		context:  browserContext,
 		tabPages: make([]*EdgeTabPage, 0),
 		locker:   sync.Mutex{},
+		proxy:    opts.Proxy,
 	}
+	pe.session = newEdgeSession("default", pe, browserContext)
+	pe.session.proxy = opts.Proxy
+	pe.sessions = []*EdgeSession{pe.session}
 
 	// 6. 创建默认标签页
 	tabPage := pe.NewTabPage("default", "about:blank")
@@ -117,26 +138,32 @@ func newEdgeBrowser(edgePath string, debugPort int) (*EdgeBrowser, error) {
 	return pe, nil
 }
 
-func (b *EdgeBrowser) addTabPage(id string, url string, page playwright.Page) *EdgeTabPage {
-	tabPage := newEdgeTabPage(id, url, b, page)
+func (b *EdgeBrowser) addTabPage(id string, url string, session *EdgeSession, page playwright.Page) *EdgeTabPage {
+	tabPage := newEdgeTabPage(id, url, b, session, page)
 	b.tabPages = append(b.tabPages, tabPage)
-	return tabPage
-}
+	session.pageIDs.Store(page, id)
 
-func (b *EdgeBrowser) removeTabPage(id string) {
-	var tabPage *EdgeTabPage
-	for i, page := range b.tabPages {
-		if page.ID() == id {
-			tabPage = b.tabPages[i]
-			b.tabPages = slices.Delete(b.tabPages, i, i+1)
-			break
+	// 默认上下文无法设置 Playwright 的代理认证选项，由标签页自行回应浏览器级代理的质询
+	if session == b.session && b.proxy != nil && b.proxy.Username != "" {
+		if err := enable_proxy_auth(page, b.proxy); err != nil {
+			log.Printf("无法为标签页 %s 启用代理认证: %v", id, err)
 		}
 	}
 
-	if tabPage != nil {
-		if !tabPage.page.IsClosed() {
-			tabPage.page.Close()
-		}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := session.emulation.apply(ctx, tabPage); err != nil {
+		log.Printf("无法为标签页 %s 应用会话模拟设置: %v", id, err)
+	}
+	return tabPage
+}
+
+// removeTabPage 按指针移除标签页，不同会话中的标签页可以使用相同的 ID
+func (b *EdgeBrowser) removeTabPage(tabPage *EdgeTabPage) {
+	b.tabPages = slices.DeleteFunc(b.tabPages, func(page *EdgeTabPage) bool { return page == tabPage })
+	tabPage.session.pageIDs.Delete(tabPage.page)
+	if !tabPage.page.IsClosed() {
+		tabPage.page.Close()
 	}
 }
 
@@ -153,6 +180,10 @@ func (b *EdgeBrowser) IsAlive() bool {
 }
 
 func (b *EdgeBrowser) NewTabPage(id string, url string) TabPage {
+	return b.newTabPage(b.session, id, url)
+}
+
+func (b *EdgeBrowser) newTabPage(session *EdgeSession, id string, url string) TabPage {
 	b.locker.Lock()
 	defer b.locker.Unlock()
 
@@ -160,7 +191,7 @@ func (b *EdgeBrowser) NewTabPage(id string, url string) TabPage {
 		url = "about:blank"
 	}
 	// 创建一个新的空白页面
-	page, err := b.context.NewPage()
+	page, err := session.context.NewPage()
 	if err != nil {
 		log.Printf("无法创建新页面: %v", err)
 		return nil
@@ -169,11 +200,11 @@ func (b *EdgeBrowser) NewTabPage(id string, url string) TabPage {
 	// 监听控制台消息
 	listen_page_console_log(page)
 
-	tabPage := b.addTabPage(id, url, page)
+	tabPage := b.addTabPage(id, url, session, page)
 
 	err = tabPage.Goto(url)
 	if err != nil {
-		b.removeTabPage(tabPage.id)
+		b.removeTabPage(tabPage)
 		log.Printf("无法打开页面: %v", err)
 		return nil
 	}
@@ -185,6 +216,115 @@ func (b *EdgeBrowser) DefaultPage() TabPage {
 	return b.FindTabPage("default")
 }
 
+func (b *EdgeBrowser) Session() Session {
+	return b.session
+}
+
+// NewSession 创建独立的浏览器上下文，不与其他会话共享 Cookies 和缓存
+func (b *EdgeBrowser) NewSession(id string, opts SessionOptions) (Session, error) {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	for _, session := range b.sessions {
+		if session.id == id {
+			return nil, fmt.Errorf("会话已存在: %s", id)
+		}
+	}
+
+	options := playwright.BrowserNewContextOptions{
+		AcceptDownloads: playwright.Bool(true),
+	}
+	if opts.Proxy == nil && b.proxies != nil {
+		proxy, err := b.proxies.NextProxy(id)
+		if err != nil {
+			return nil, fmt.Errorf("无法为会话 %s 分配代理: %w", id, err)
+		}
+		opts.Proxy = proxy
+	}
+	if opts.Proxy == nil && b.proxy != nil {
+		// 新上下文默认沿用浏览器级代理，显式设置后由 Playwright 回应代理认证
+		opts.Proxy = b.proxy
+	}
+	if opts.Proxy != nil {
+		if err := opts.Proxy.validate(); err != nil {
+			return nil, err
+		}
+		options.Proxy = opts.Proxy.contextProxy()
+	}
+	if opts.BlockServiceWorkers {
+		options.ServiceWorkers = playwright.ServiceWorkerPolicyBlock
+	}
+	if opts.Video != nil {
+		video := *opts.Video
+		opts.Video = &video
+		options.RecordVideo = record_video_options(opts.Video)
+	}
+	// 持有浏览器锁时创建前后的上下文列表之差即为新上下文在 CDP 中的ID，权限设置不再依赖标签页
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	existing, err := browser_context_ids(ctx, b.browser)
+	if err != nil {
+		return nil, err
+	}
+	browserContext, err := b.browser.NewContext(options)
+	if err != nil {
+		return nil, fmt.Errorf("无法创建浏览器上下文: %w", err)
+	}
+	contextIDs, err := browser_context_ids(ctx, b.browser)
+	if err != nil {
+		browserContext.Close()
+		return nil, err
+	}
+	contextIDs = slices.DeleteFunc(contextIDs, func(item string) bool { return slices.Contains(existing, item) })
+	if len(contextIDs) != 1 {
+		browserContext.Close()
+		return nil, fmt.Errorf("无法确定会话 %s 的浏览器上下文ID: %v", id, contextIDs)
+	}
+
+	session := newEdgeSession(id, b, browserContext)
+	session.contextID = contextIDs[0]
+	session.recording.video = opts.Video
+	session.proxy = opts.Proxy
+	if opts.Trace != nil {
+		if err := session.recording.startTrace(browserContext, id, *opts.Trace); err != nil {
+			browserContext.Close()
+			return nil, err
+		}
+	}
+	if opts.BlockServiceWorkers {
+		if err := session.workers.block(session.routes); err != nil {
+			browserContext.Close()
+			return nil, err
+		}
+	}
+	b.sessions = append(b.sessions, session)
+	log.Printf("已创建会话: %s", id)
+	return session, nil
+}
+
+func (b *EdgeBrowser) FindSession(id string) Session {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	for _, session := range b.sessions {
+		if session.id == id {
+			return session
+		}
+	}
+	return nil
+}
+
+func (b *EdgeBrowser) Sessions() []Session {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+
+	sessions := make([]Session, 0, len(b.sessions))
+	for _, session := range b.sessions {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
 func (b *EdgeBrowser) FindTabPage(id string) TabPage {
 	for _, page := range b.tabPages {
 		if page.ID() == id {
@@ -221,7 +361,7 @@ func (b *EdgeBrowser) CloseTabPage(id string) error {
 		return fmt.Errorf("未找到标签页: %s", id)
 	}
 
-	b.removeTabPage(id)
+	b.removeTabPage(tabPage.(*EdgeTabPage))
 	return nil
 }
 
@@ -229,6 +369,12 @@ func (b *EdgeBrowser) Close() error {
 	b.locker.Lock()
 	defer b.locker.Unlock()
 
+	for _, session := range slices.Clone(b.sessions) {
+		if err := session.close(); err != nil {
+			log.Printf("关闭会话 %s 失败: %v", session.id, err)
+		}
+	}
+
 	for _, page := range b.tabPages {
 		if !page.page.IsClosed() {
 			page.page.Close()
@@ -244,3 +390,24 @@ func (b *EdgeBrowser) Close() error {
 	b.pw.Stop()
 	return nil
 }
+
+// SetDialogPolicy 设置所有标签页默认的对话框策略
+func (b *EdgeBrowser) SetDialogPolicy(policy DialogPolicy) {
+	b.dialogs.setPolicy(&policy)
+}
+
+func (b *EdgeBrowser) Dialogs() []DialogRecord {
+	return b.dialogs.list()
+}
+
+// SetProxyProvider 设置代理分配器，之后未指定代理的新会话由其分配代理，传入 nil 取消
+func (b *EdgeBrowser) SetProxyProvider(provider ProxyProvider) {
+	b.locker.Lock()
+	defer b.locker.Unlock()
+	b.proxies = provider
+}
+
+// SetRetryPolicy 设置浏览器级重试策略，作用于 Goto、Navigate 和 Element.Click，传入零值取消
+func (b *EdgeBrowser) SetRetryPolicy(policy RetryPolicy) {
+	b.retry.Store(&policy)
+}
@@ -0,0 +1,156 @@
+package handle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// EdgeElement 基于 Playwright Locator 的页面元素，每次操作时重新查找，不会因页面更新而失效
+type EdgeElement struct {
+	selector string             // 元素的选择器，用于错误信息
+	locator  playwright.Locator // 元素定位器
+	tab      *EdgeTabPage       // 所属标签页，提供浏览器级重试策略和失败现场采集，可为空
+}
+
+func newEdgeElement(selector string, locator playwright.Locator, tab *EdgeTabPage) *EdgeElement {
+	return &EdgeElement{selector: selector, locator: locator, tab: tab}
+}
+
+func (e *EdgeElement) Selector() string {
+	return e.selector
+}
+
+// Locator 返回底层的 Playwright Locator，仅 *EdgeElement 提供，需要时通过类型断言获取
+func (e *EdgeElement) Locator() playwright.Locator {
+	return e.locator
+}
+
+// Click 等待元素可点击后点击，失败时按 WithRetry 或浏览器级重试策略重试
+func (e *EdgeElement) Click(ctx context.Context) error {
+	var fallback *RetryPolicy
+	if e.tab != nil {
+		fallback = e.tab.browser.retry.Load()
+	}
+	var reload func(ctx context.Context) error
+	if page, err := e.locator.Page(); err == nil {
+		reload = reload_page(page)
+	}
+	// 元素不存在导致的超时重试也不会恢复，不再重试
+	policy := resolve_retry_policy(ctx, fallback)
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsTransientError
+	}
+	policy.Retryable = func(err error) bool {
+		return !errors.Is(err, ErrElementNotFound) && retryable(err)
+	}
+	err := retry(ctx, policy, reload, func(ctx context.Context) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := e.locator.Click(playwright.LocatorClickOptions{Timeout: ctx_timeout(ctx)})
+		if err != nil && e.missing() {
+			return errors.Join(ErrElementNotFound, err)
+		}
+		return err
+	})
+	return e.fail("Click", err)
+}
+
+// Fill 清空输入框后填入 value
+func (e *EdgeElement) Fill(ctx context.Context, value string) error {
+	if err := ctx.Err(); err != nil {
+		return e.fail("Fill", err)
+	}
+	err := e.locator.Fill(value, playwright.LocatorFillOptions{Timeout: ctx_timeout(ctx)})
+	return e.fail("Fill", err)
+}
+
+// Text 返回元素渲染后的文本
+func (e *EdgeElement) Text(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", e.fail("Text", err)
+	}
+	text, err := e.locator.InnerText(playwright.LocatorInnerTextOptions{Timeout: ctx_timeout(ctx)})
+	return text, e.fail("Text", err)
+}
+
+// Attr 返回属性值，属性不存在时返回空字符串
+func (e *EdgeElement) Attr(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", e.fail("Attr", err)
+	}
+	value, err := e.locator.GetAttribute(name, playwright.LocatorGetAttributeOptions{Timeout: ctx_timeout(ctx)})
+	return value, e.fail("Attr", err)
+}
+
+// Visible 立即返回元素当前是否可见，元素不存在时返回 false
+func (e *EdgeElement) Visible(ctx context.Context) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, e.fail("Visible", err)
+	}
+	visible, err := e.locator.IsVisible()
+	return visible, e.fail("Visible", err)
+}
+
+// BoundingBox 返回元素相对于视口的区域，元素不可见时返回 nil
+func (e *EdgeElement) BoundingBox(ctx context.Context) (*ClipRect, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, e.fail("BoundingBox", err)
+	}
+	rect, err := e.locator.BoundingBox(playwright.LocatorBoundingBoxOptions{Timeout: ctx_timeout(ctx)})
+	if err != nil {
+		return nil, e.fail("BoundingBox", err)
+	}
+	if rect == nil {
+		return nil, nil
+	}
+	return &ClipRect{X: rect.X, Y: rect.Y, Width: rect.Width, Height: rect.Height}, nil
+}
+
+// Children 返回元素的直接子元素
+func (e *EdgeElement) Children(ctx context.Context) ([]Element, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, e.fail("Children", err)
+	}
+	items, err := e.locator.Locator(":scope > *").All()
+	if err != nil {
+		return nil, e.fail("Children", err)
+	}
+	children := make([]Element, 0, len(items))
+	for i, item := range items {
+		children = append(children, newEdgeElement(fmt.Sprintf("%s >> :scope > * >> nth=%d", e.selector, i), item, e.tab))
+	}
+	return children, nil
+}
+
+// Screenshot 截取元素，忽略 opts 中的 FullPage、Selector 和 Clip
+func (e *EdgeElement) Screenshot(ctx context.Context, opts ScreenshotOptions) ([]byte, error) {
+	data, err := take_element_screenshot(ctx, e.locator, opts)
+	return data, e.fail("Screenshot", err)
+}
+
+// fail 将错误包装为 *ElementError，元素不存在时附加 ErrElementNotFound；
+// 所属标签页开启失败现场采集时再包装为 *ArtifactError
+func (e *EdgeElement) fail(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrElementNotFound) && e.missing() {
+		err = errors.Join(ErrElementNotFound, err)
+	}
+	err = &ElementError{Op: op, Selector: e.selector, Err: err}
+	if e.tab != nil {
+		return e.tab.fail("Element."+op, err)
+	}
+	return err
+}
+
+// missing 选择器当前是否未匹配到任何元素，查询失败时视为存在
+func (e *EdgeElement) missing() bool {
+	count, err := e.locator.Count()
+	return err == nil && count == 0
+}
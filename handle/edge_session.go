@@ -0,0 +1,321 @@
+package handle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// EdgeSession 对应一个浏览器上下文，上下文内的所有标签页共享 Cookies、存储和会话级规则
+type EdgeSession struct {
+	id        string                        // 会话ID
+	browser   *EdgeBrowser                  // 浏览器实例
+	context   playwright.BrowserContext     // 浏览器上下文
+	routes    *routeTable                   // 会话级路由规则
+	workers   serviceWorkerBlock            // Service Worker 屏蔽
+	requests  *eventHub[playwright.Request] // 请求完成或失败事件分发
+	downloads *DownloadManager              // 下载管理
+	recording sessionRecording              // 追踪与视频录制
+	emulation emulationState                // 网络和 CPU 模拟
+	proxy     *ProxyConfig                  // 会话使用的代理，为空表示直连
+	contextID string                        // 上下文在 CDP 中的ID，默认会话为空
+	modules   sync.Map                      // 已注册的 JS 模块名
+	pageIDs   sync.Map                      // 页面到标签页ID，绑定函数回调中查询，不能占用浏览器锁
+	closed    bool                          // 是否已关闭
+}
+
+func newEdgeSession(id string, browser *EdgeBrowser, context playwright.BrowserContext) *EdgeSession {
+	session := &EdgeSession{
+		id:        id,
+		browser:   browser,
+		context:   context,
+		requests:  newEventHub[playwright.Request](),
+		downloads: newDownloadManager(),
+	}
+	context.OnRequestFinished(session.requests.dispatch)
+	context.OnRequestFailed(session.requests.dispatch)
+	session.routes = newRouteTable(
+		func(handler func(playwright.Route)) error { return context.Route("**/*", handler) },
+		func(handler func(playwright.Route)) error { return context.Unroute("**/*", handler) },
+	)
+	session.workers.binding = "__handleBlockedServiceWorkerForSession"
+	session.workers.target = context
+	session.workers.pages = context.Pages
+	return session
+}
+
+func (s *EdgeSession) ID() string {
+	return s.id
+}
+
+func (s *EdgeSession) Context() playwright.BrowserContext {
+	return s.context
+}
+
+// Proxy 返回会话使用的代理，直连时为 nil
+func (s *EdgeSession) Proxy() *ProxyConfig {
+	return s.proxy
+}
+
+func (s *EdgeSession) Route(name string, matcher RouteMatcher, action RouteAction) error {
+	return s.routes.add(name, matcher, action)
+}
+
+func (s *EdgeSession) Unroute(name string) error {
+	return s.routes.remove(name)
+}
+
+func (s *EdgeSession) RouteNames() []string {
+	return s.routes.names()
+}
+
+// BlockResources 在会话内屏蔽预设的资源；标签页级路由规则先于会话级规则执行，
+// 标签页上匹配的 ContinueRoute 等规则会放行会话屏蔽的请求
+func (s *EdgeSession) BlockResources(presets ...BlockPreset) error {
+	return block_resources(s.routes, &s.workers, presets)
+}
+
+func (s *EdgeSession) UnblockResources(presets ...BlockPreset) error {
+	return unblock_resources(s.routes, presets)
+}
+
+func (s *EdgeSession) BlockedCounts() map[BlockPreset]int64 {
+	return blocked_counts(s.routes)
+}
+
+func (s *EdgeSession) RecordHAR(path string, opts HAROptions) (*HARRecorder, error) {
+	return new_har_recorder(s.requests, path, opts)
+}
+
+// ReplayHAR 使用 HAR 文件响应会话内所有标签页的请求，返回停止回放的函数；
+// Playwright 只在会话关闭时释放 HAR 文件，停止回放后文件仍保持打开
+func (s *EdgeSession) ReplayHAR(path string, opts HARReplayOptions) (func() error, error) {
+	pattern, err := unique_route_pattern(opts.URLGlob)
+	if err != nil {
+		return nil, err
+	}
+	options := playwright.BrowserContextRouteFromHAROptions{NotFound: har_not_found(opts), URL: pattern}
+	har_check_replay(path)
+	if err := s.context.RouteFromHAR(path, options); err != nil {
+		return nil, fmt.Errorf("无法加载 HAR 文件: %w", err)
+	}
+	return func() error {
+		if err := s.context.Unroute(pattern); err != nil {
+			return fmt.Errorf("无法停止 HAR 回放: %w", err)
+		}
+		return nil
+	}, nil
+}
+
+func (s *EdgeSession) Downloads() *DownloadManager {
+	return s.downloads
+}
+
+func (s *EdgeSession) NewTabPage(id string, url string) TabPage {
+	return s.browser.newTabPage(s, id, url)
+}
+
+func (s *EdgeSession) TabPages() []TabPage {
+	s.browser.locker.Lock()
+	defer s.browser.locker.Unlock()
+
+	tabPages := make([]TabPage, 0)
+	for _, page := range s.browser.tabPages {
+		if page.session == s {
+			tabPages = append(tabPages, page)
+		}
+	}
+	return tabPages
+}
+
+// StartTracing 开始录制 Playwright 追踪，会话关闭时按保留策略保存
+func (s *EdgeSession) StartTracing(opts TraceOptions) error {
+	return s.recording.startTrace(s.context, s.id, opts)
+}
+
+// MarkFailed 标记会话对应的任务失败，保留策略为 RetainOnFailure 的录制将在关闭时保存
+func (s *EdgeSession) MarkFailed(err error) {
+	s.recording.markFailed(err)
+}
+
+// Recordings 返回已保存的追踪和视频文件
+func (s *EdgeSession) Recordings() []string {
+	return s.recording.savedFiles()
+}
+
+// Close 关闭会话及其标签页并按保留策略保存录制；默认会话只结束录制，不关闭标签页和上下文
+func (s *EdgeSession) Close() error {
+	s.browser.locker.Lock()
+	defer s.browser.locker.Unlock()
+	return s.close()
+}
+
+// close 调用方需持有浏览器锁
+func (s *EdgeSession) close() error {
+	if s.closed {
+		return nil
+	}
+	var errs []error
+	if err := s.recording.stopTrace(s.context, s.id); err != nil {
+		errs = append(errs, err)
+	}
+	if s == s.browser.session {
+		return errors.Join(errs...)
+	}
+	s.closed = true
+
+	videos := make(map[string]playwright.Video)
+	for _, page := range slices.Clone(s.browser.tabPages) {
+		if page.session != s {
+			continue
+		}
+		if video := page.page.Video(); video != nil {
+			videos[page.id] = video
+		}
+		s.browser.removeTabPage(page)
+	}
+	if err := s.context.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("无法关闭浏览器上下文: %w", err))
+	}
+	if err := s.recording.saveVideos(videos); err != nil {
+		errs = append(errs, err)
+	}
+	s.browser.sessions = slices.DeleteFunc(s.browser.sessions, func(item *EdgeSession) bool { return item == s })
+	log.Printf("已关闭会话: %s", s.id)
+	return errors.Join(errs...)
+}
+
+// EmulateNetwork 为会话内现有和之后新建的标签页模拟网络条件
+func (s *EdgeSession) EmulateNetwork(ctx context.Context, conditions NetworkConditions) error {
+	s.emulation.setNetwork(&conditions)
+	var errs []error
+	for _, tab := range s.TabPages() {
+		errs = append(errs, tab.EmulateNetwork(ctx, conditions))
+	}
+	return errors.Join(errs...)
+}
+
+func (s *EdgeSession) ClearNetworkEmulation(ctx context.Context) error {
+	s.emulation.setNetwork(nil)
+	var errs []error
+	for _, tab := range s.TabPages() {
+		errs = append(errs, tab.ClearNetworkEmulation(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// SetCPUThrottling 为会话内现有和之后新建的标签页设置 CPU 降速倍数，1 表示取消降速
+func (s *EdgeSession) SetCPUThrottling(ctx context.Context, rate float64) error {
+	if rate < 1 {
+		return fmt.Errorf("CPU 降速倍数不能小于 1: %v", rate)
+	}
+	s.emulation.setCPURate(rate)
+	var errs []error
+	for _, tab := range s.TabPages() {
+		errs = append(errs, tab.SetCPUThrottling(ctx, rate))
+	}
+	return errors.Join(errs...)
+}
+
+// GrantPermissions 授予权限，origin 为空时对所有来源生效；与 DenyPermissions 一样逐项设置，不会覆盖其他权限
+func (s *EdgeSession) GrantPermissions(origin string, permissions ...Permission) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return s.setPermissions(ctx, origin, permissions, PermissionGranted)
+}
+
+// DenyPermissions 拒绝权限，origin 为空时对所有来源生效
+func (s *EdgeSession) DenyPermissions(ctx context.Context, origin string, permissions ...Permission) error {
+	return s.setPermissions(ctx, origin, permissions, PermissionDenied)
+}
+
+func (s *EdgeSession) setPermissions(ctx context.Context, origin string, permissions []Permission, setting PermissionSetting) error {
+	for _, permission := range permissions {
+		if err := set_permission(ctx, s.browser.browser, s.contextID, origin, permission, setting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClearPermissions 将所有权限恢复为默认状态
+func (s *EdgeSession) ClearPermissions(ctx context.Context) error {
+	return reset_permissions(ctx, s.browser.browser, s.contextID)
+}
+
+// SetGeolocation 设置会话内所有页面的地理位置，立即生效，传入 nil 取消；页面需要 PermissionGeolocation 权限才能读取
+func (s *EdgeSession) SetGeolocation(location *Geolocation) error {
+	var geolocation *playwright.Geolocation
+	if location != nil {
+		geolocation = &playwright.Geolocation{
+			Latitude:  location.Latitude,
+			Longitude: location.Longitude,
+			Accuracy:  playwright.Float(location.Accuracy),
+		}
+	}
+	if err := s.context.SetGeolocation(geolocation); err != nil {
+		return fmt.Errorf("无法设置地理位置: %w", err)
+	}
+	return nil
+}
+
+// SetTimezone 为会话内现有和之后新建的标签页覆盖时区，为空时取消覆盖
+func (s *EdgeSession) SetTimezone(ctx context.Context, timezoneID string) error {
+	s.emulation.setTimezone(timezoneID)
+	var errs []error
+	for _, tab := range s.TabPages() {
+		errs = append(errs, tab.SetTimezone(ctx, timezoneID))
+	}
+	return errors.Join(errs...)
+}
+
+// SetLocale 为会话内现有和之后新建的标签页覆盖语言区域，为空时取消覆盖
+func (s *EdgeSession) SetLocale(ctx context.Context, locale string) error {
+	s.emulation.setLocale(locale)
+	var errs []error
+	for _, tab := range s.TabPages() {
+		errs = append(errs, tab.SetLocale(ctx, locale))
+	}
+	return errors.Join(errs...)
+}
+
+// RegisterModule 注册 CommonJS 风格的 JS 模块(通过 exports 或 module.exports 导出函数)，
+// 会话内现有和之后打开的页面都可以通过 TabPage.CallModule 调用，每个模块名只能注册一次
+func (s *EdgeSession) RegisterModule(name string, source string) error {
+	if _, loaded := s.modules.LoadOrStore(name, true); loaded {
+		return fmt.Errorf("模块已注册: %s", name)
+	}
+	script := module_script(name, source)
+	if err := s.context.AddInitScript(playwright.Script{Content: playwright.String(script)}); err != nil {
+		s.modules.Delete(name)
+		return fmt.Errorf("无法注册模块 %s: %w", name, err)
+	}
+	var errs []error
+	for _, tab := range s.TabPages() {
+		if _, err := tab.Page().Evaluate(script); err != nil {
+			errs = append(errs, fmt.Errorf("无法在标签页 %s 中加载模块 %s: %w", tab.ID(), name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ExposeFunc 在会话内所有页面的 window 上添加函数 name，包括之后打开的页面
+func (s *EdgeSession) ExposeFunc(name string, fn BindingFunc) error {
+	callback := binding_callback(name, fn, func(page playwright.Page) string {
+		// 回调可能在持有浏览器锁的调用(如关闭标签页)等待页面时触发，不能调用 TabPages
+		if id, ok := s.pageIDs.Load(page); ok {
+			return id.(string)
+		}
+		return ""
+	})
+	if err := s.context.ExposeBinding(name, callback); err != nil {
+		return fmt.Errorf("无法暴露函数 %s: %w", name, err)
+	}
+	return nil
+}
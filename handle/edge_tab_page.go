@@ -3,29 +3,66 @@ package handle
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
+	"regexp"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/playwright-community/playwright-go"
 )
 
 type EdgeTabPage struct {
-	id      string          // 标签页ID
-	url     string          // 标签页初始URL
-	browser *EdgeBrowser    // 浏览器实例
-	page    playwright.Page // 标签页实例
+	id        string                          // 标签页ID
+	url       string                          // 标签页初始URL
+	browser   *EdgeBrowser                    // 浏览器实例
+	session   *EdgeSession                    // 所属会话
+	page      playwright.Page                 // 标签页实例
+	routes    *routeTable                     // 标签页级路由规则
+	workers   serviceWorkerBlock              // Service Worker 屏蔽
+	responses *eventHub[playwright.Response]  // 响应事件分发
+	requests  *eventHub[playwright.Request]   // 请求完成或失败事件分发
+	started   *eventHub[playwright.Request]   // 请求发出事件分发
+	dialogs   dialogState                     // 对话框策略和记录
+	console   consoleLog                      // 最近的控制台消息
+	artifacts atomic.Pointer[ArtifactOptions] // 失败现场采集配置，为空时不采集
+	cdpLocker sync.Mutex                      // 保护 cdp
+	cdp       *CDPSession                     // 首次调用 CDP() 时创建
 }
 
-func newEdgeTabPage(id string, url string, browser *EdgeBrowser, page playwright.Page) *EdgeTabPage {
+func newEdgeTabPage(id string, url string, browser *EdgeBrowser, session *EdgeSession, page playwright.Page) *EdgeTabPage {
 
 	tabPage := &EdgeTabPage{
-		id:      id,
-		url:     url,
-		browser: browser,
-		page:    page,
+		id:        id,
+		url:       url,
+		browser:   browser,
+		session:   session,
+		page:      page,
+		responses: newEventHub[playwright.Response](),
+		requests:  newEventHub[playwright.Request](),
+		started:   newEventHub[playwright.Request](),
 	}
+	page.OnResponse(tabPage.responses.dispatch)
+	page.OnRequest(tabPage.started.dispatch)
+	page.OnRequestFinished(tabPage.requests.dispatch)
+	page.OnRequestFailed(tabPage.requests.dispatch)
+	page.OnDownload(func(download playwright.Download) {
+		session.downloads.handle(id, download)
+	})
+	page.OnConsole(tabPage.console.append)
+	page.OnDialog(func(dialog playwright.Dialog) {
+		handle_dialog(id, dialog, &tabPage.dialogs, &browser.dialogs)
+	})
+	tabPage.routes = newRouteTable(
+		func(handler func(playwright.Route)) error { return page.Route("**/*", handler) },
+		func(handler func(playwright.Route)) error { return page.Unroute("**/*", handler) },
+	)
+	tabPage.workers.binding = "__handleBlockedServiceWorkerForTab"
+	tabPage.workers.target = page
+	tabPage.workers.pages = func() []playwright.Page { return []playwright.Page{page} }
 
 	return tabPage
 }
@@ -89,7 +126,7 @@ func (t *EdgeTabPage) OpenInNewTab(id string, action func() error, timeout float
 		default:
 		}
 
-		newPage, err := t.browser.context.WaitForEvent("page", playwright.BrowserContextWaitForEventOptions{
+		newPage, err := t.session.context.WaitForEvent("page", playwright.BrowserContextWaitForEventOptions{
 			Predicate: func(event any) bool { return true },
 			Timeout:   playwright.Float(timeout),
 		})
@@ -124,7 +161,7 @@ func (t *EdgeTabPage) OpenInNewTab(id string, action func() error, timeout float
 
 	select {
 	case newPage := <-newPageChan:
-		tabPage := t.browser.addTabPage(id, newPage.URL(), newPage)
+		tabPage := t.browser.addTabPage(id, newPage.URL(), t.session, newPage)
 		log.Printf("成功捕获新标签页, ID: %s", id)
 		return tabPage
 	case <-ctx.Done():
@@ -134,37 +171,20 @@ func (t *EdgeTabPage) OpenInNewTab(id string, action func() error, timeout float
 	}
 }
 
-func (t *EdgeTabPage) WaitSelector(selector string, timeout float64) playwright.Locator {
-	locator := t.page.Locator(selector)
-	if locator == nil {
-		log.Printf("无法找到选择器: %s", selector)
-		return nil
-	}
-	// await expect(lolocator).toHaveCount(1)
-	count, err := locator.Count()
-	if err != nil {
-		log.Printf("无法获取选择器数量: %v", err)
-		return nil
-	}
-	if count == 0 {
-		log.Printf("选择器未匹配到任何元素: %s", selector)
-		return nil
-	}
-	if count > 1 {
-		log.Printf("选择器匹配到多个元素: %s, 取第一条返回", selector)
-		locator = locator.First()
-	}
-	err = locator.WaitFor(playwright.LocatorWaitForOptions{
-		State:   playwright.WaitForSelectorStateVisible,
-		Timeout: playwright.Float(timeout),
-	})
+// WaitSelector 等待元素可见，超时或出错时返回 nil
+func (t *EdgeTabPage) WaitSelector(selector string, timeout float64) Element {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Millisecond)
+	defer cancel()
+
+	element, err := wait_for_element(ctx, t.page, t, selector, ElementVisible)
 	if err != nil {
+		log.Printf("等待选择器 %s 失败: %v", selector, err)
 		return nil
 	}
-	return locator
+	return element
 }
 
-func (t *EdgeTabPage) QuerySelector(selector string) playwright.Locator {
+func (t *EdgeTabPage) QuerySelector(selector string) Element {
 	locator := t.page.Locator(selector)
 	if locator == nil {
 		log.Printf("无法找到选择器: %s", selector)
@@ -183,33 +203,37 @@ func (t *EdgeTabPage) QuerySelector(selector string) playwright.Locator {
 		log.Printf("选择器匹配到多个元素: %s, 取第一条返回", selector)
 		locator = locator.First()
 	}
-	return locator
+	return newEdgeElement(selector, locator, t)
 }
 
-func (t *EdgeTabPage) QuerySelectorAll(selector string) []playwright.Locator {
+func (t *EdgeTabPage) QuerySelectorAll(selector string) []Element {
 	locator := t.page.Locator(selector)
 	if locator == nil {
 		log.Printf("无法找到选择器: %s", selector)
-		return []playwright.Locator{}
+		return []Element{}
 	}
 	count, err := locator.Count()
 	if err != nil {
 		log.Printf("无法获取选择器数量: %v", err)
-		return []playwright.Locator{}
+		return []Element{}
 	}
 	if count == 0 {
 		log.Printf("选择器未匹配到任何元素: %s", selector)
-		return []playwright.Locator{}
+		return []Element{}
 	}
 	if count == 1 {
-		return []playwright.Locator{locator}
+		return []Element{newEdgeElement(selector, locator, t)}
 	}
 	items, err := locator.All()
 	if err != nil {
 		log.Printf("无法获取所有选择器: %v", err)
-		return []playwright.Locator{}
+		return []Element{}
+	}
+	elements := make([]Element, 0, len(items))
+	for i, item := range items {
+		elements = append(elements, newEdgeElement(fmt.Sprintf("%s >> nth=%d", selector, i), item, t))
 	}
-	return items
+	return elements
 }
 
 func (t *EdgeTabPage) ClearLocalData() error {
@@ -220,7 +244,7 @@ func (t *EdgeTabPage) ClearLocalData() error {
 	if _, err := t.page.Evaluate("sessionStorage.clear()"); err != nil {
 		return fmt.Errorf("清空 sessionStorage 失败: %w", err)
 	}
-	if err := t.browser.context.ClearCookies(); err != nil {
+	if err := t.session.context.ClearCookies(); err != nil {
 		return fmt.Errorf("清除 Cookies 失败: %w", err)
 	}
 	if _, err := t.page.Evaluate(`
@@ -239,34 +263,128 @@ func (t *EdgeTabPage) ClearLocalData() error {
 }
 
 func (t *EdgeTabPage) Goto(url string) error {
-	// 导航
-	_, err := t.page.Goto(url, playwright.PageGotoOptions{
-		WaitUntil: playwright.WaitUntilStateDomcontentloaded,
+	_, err := t.Navigate(context.Background(), url, NavigateOptions{})
+	return err
+}
+
+// Navigate 打开 url 并按 opts 等待加载完成，返回最终地址、状态码、重定向链和耗时；
+// 失败时按 WithRetry 或浏览器级重试策略重新导航
+func (t *EdgeTabPage) Navigate(ctx context.Context, url string, opts NavigateOptions) (*NavigationResult, error) {
+	var result *NavigationResult
+	err := retry(ctx, resolve_retry_policy(ctx, t.browser.retry.Load()), nil, func(ctx context.Context) error {
+		var err error
+		result, err = navigate(ctx, t.page, t.browser.port, url, opts)
+		return err
 	})
 	if err != nil {
-		return fmt.Errorf("无法访问网站: %v", err)
+		return nil, t.fail("Navigate", err)
 	}
+	return result, nil
+}
 
-	block_debug_port_detector(t.page, t.browser.port)
+// Evaluate 执行 JS 表达式，不重试；脚本可能有副作用，需要重试时由调用方通过 Retry 显式指定
+func (t *EdgeTabPage) Evaluate(expression string, arg ...any) (any, error) {
+	result, err := t.page.Evaluate(expression, arg...)
+	return result, t.fail("Evaluate", err)
+}
 
-	// 等待页面完全加载
-	err = t.page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{
-		State: playwright.LoadStateLoad,
+// EvaluateInto 执行 JS 表达式并将结果 JSON 解码到 out，参数按 json 标签序列化；多个参数时 expression 必须是函数，参数依次传入
+func (t *EdgeTabPage) EvaluateInto(ctx context.Context, out any, expression string, args ...any) error {
+	return t.fail("EvaluateInto", t.evaluateInto(ctx, out, expression, args...))
+}
+
+// evaluateInto EvaluateInto 的实现，不采集失败现场，供 Extract 等以自身的操作名采集
+func (t *EdgeTabPage) evaluateInto(ctx context.Context, out any, expression string, args ...any) error {
+	values, err := js_values(args)
+	if err != nil {
+		return err
+	}
+	result, err := evaluate_with_ctx(ctx, func() (any, error) {
+		switch len(values) {
+		case 0:
+			return t.page.Evaluate(expression)
+		case 1:
+			return t.page.Evaluate(expression, values[0])
+		default:
+			return t.page.Evaluate(fmt.Sprintf("(args) => (%s)(...args)", expression), values)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return decode_js_result(result, out)
+}
+
+// CallModule 调用通过 Session.RegisterModule 注册的模块函数，结果 JSON 解码到 out，out 可为 nil
+func (t *EdgeTabPage) CallModule(ctx context.Context, out any, module string, fn string, args ...any) error {
+	values, err := js_values(args)
+	if err != nil {
+		return err
+	}
+	result, err := evaluate_with_ctx(ctx, func() (any, error) {
+		return t.page.Evaluate(callModuleScript, []any{module, fn, values})
 	})
 	if err != nil {
-		return fmt.Errorf("等待页面加载失败: %v", err)
+		return t.fail("CallModule", err)
 	}
-	log.Printf("已成功访问网站: %s", url)
+	return t.fail("CallModule", decode_js_result(result, out))
+}
+
+// Extract 按 out 结构体字段的 sel、attr、parse 标签提取页面数据，一次 Evaluate 完成；
+// 嵌套结构体在上级元素内提取，切片字段对应匹配的所有元素
+func (t *EdgeTabPage) Extract(ctx context.Context, out any) error {
+	target, fields, err := extract_target(out)
+	if err != nil {
+		return err
+	}
+	var data map[string]any
+	if err := t.evaluateInto(ctx, &data, extractScript, fields); err != nil {
+		return t.fail("Extract", fmt.Errorf("提取页面数据失败: %w", err))
+	}
+	return t.fail("Extract", assign_extracted(target, fields, data))
+}
+
+// ExtractTable 提取 selector 匹配的表格，展开 colspan、rowspan，识别 thead 或开头由 th 组成的行作为表头
+func (t *EdgeTabPage) ExtractTable(ctx context.Context, selector string) (*Table, error) {
+	var grid *tableGrid
+	if err := t.evaluateInto(ctx, &grid, tableScript, selector); err != nil {
+		return nil, t.fail("ExtractTable", &ElementError{Op: "ExtractTable", Selector: selector, Err: err})
+	}
+	if grid == nil {
+		return nil, t.fail("ExtractTable", &ElementError{Op: "ExtractTable", Selector: selector, Err: ErrElementNotFound})
+	}
+	return new_table(*grid), nil
+}
+
+// ExtractList 提取 selector 匹配的列表中每一项的文本，ul、ol 只取 li，其他元素取所有直接子元素
+func (t *EdgeTabPage) ExtractList(ctx context.Context, selector string) ([]string, error) {
+	var items []string
+	if err := t.evaluateInto(ctx, &items, listScript, selector); err != nil {
+		return nil, t.fail("ExtractList", &ElementError{Op: "ExtractList", Selector: selector, Err: err})
+	}
+	if items == nil {
+		return nil, t.fail("ExtractList", &ElementError{Op: "ExtractList", Selector: selector, Err: ErrElementNotFound})
+	}
+	return items, nil
+}
 
+// ExposeFunc 在本标签页的 window 上添加函数 name，页面 JS 调用时返回 Promise，由 fn 在 Go 中处理；
+// 页面跳转后仍然有效
+func (t *EdgeTabPage) ExposeFunc(name string, fn BindingFunc) error {
+	callback := binding_callback(name, fn, func(playwright.Page) string { return t.id })
+	if err := t.page.ExposeBinding(name, callback); err != nil {
+		return fmt.Errorf("无法暴露函数 %s: %w", name, err)
+	}
 	return nil
 }
 
-func (t *EdgeTabPage) Evaluate(expression string, arg ...any) (any, error) {
-	return t.page.Evaluate(expression, arg...)
+// Retry 按策略重试 op，策略开启 ReloadOnRetry 时因导航类错误失败后会先重新加载本页面
+func (t *EdgeTabPage) Retry(ctx context.Context, policy RetryPolicy, op func(ctx context.Context) error) error {
+	return t.fail("Retry", retry(ctx, policy, reload_page(t.page), op))
 }
 
 func (t *EdgeTabPage) Close() {
-	t.browser.removeTabPage(t.id)
+	t.browser.removeTabPage(t)
 }
 
 func (t *EdgeTabPage) Reload() error {
@@ -309,3 +427,269 @@ func (t *EdgeTabPage) SleepRandom(min, max int) {
 	sleepTime := min + rand.Intn(max-min+1)
 	time.Sleep(time.Duration(sleepTime) * time.Millisecond)
 }
+
+func (t *EdgeTabPage) Route(name string, matcher RouteMatcher, action RouteAction) error {
+	return t.routes.add(name, matcher, action)
+}
+
+func (t *EdgeTabPage) Unroute(name string) error {
+	return t.routes.remove(name)
+}
+
+func (t *EdgeTabPage) RouteNames() []string {
+	return t.routes.names()
+}
+
+func (t *EdgeTabPage) BlockResources(presets ...BlockPreset) error {
+	return block_resources(t.routes, &t.workers, presets)
+}
+
+func (t *EdgeTabPage) UnblockResources(presets ...BlockPreset) error {
+	return unblock_resources(t.routes, presets)
+}
+
+func (t *EdgeTabPage) BlockedCounts() map[BlockPreset]int64 {
+	return blocked_counts(t.routes)
+}
+
+func (t *EdgeTabPage) WaitForResponse(ctx context.Context, matcher RouteMatcher, action func() error) (*CapturedResponse, error) {
+	response, err := wait_for_response(ctx, t.responses, matcher, action)
+	return response, t.fail("WaitForResponse", err)
+}
+
+func (t *EdgeTabPage) RecordResponses(ctx context.Context, matcher RouteMatcher, buffer int) (*ResponseRecorder, error) {
+	return new_response_recorder(ctx, t.responses, matcher, buffer)
+}
+
+func (t *EdgeTabPage) RecordHAR(path string, opts HAROptions) (*HARRecorder, error) {
+	return new_har_recorder(t.requests, path, opts)
+}
+
+// ReplayHAR 使用 HAR 文件响应请求，返回停止回放的函数；
+// Playwright 只在标签页关闭时释放 HAR 文件，停止回放后文件仍保持打开
+func (t *EdgeTabPage) ReplayHAR(path string, opts HARReplayOptions) (func() error, error) {
+	pattern, err := unique_route_pattern(opts.URLGlob)
+	if err != nil {
+		return nil, err
+	}
+	options := playwright.PageRouteFromHAROptions{NotFound: har_not_found(opts), URL: pattern}
+	har_check_replay(path)
+	if err := t.page.RouteFromHAR(path, options); err != nil {
+		return nil, fmt.Errorf("无法加载 HAR 文件: %w", err)
+	}
+	return func() error {
+		if err := t.page.Unroute(pattern); err != nil {
+			return fmt.Errorf("无法停止 HAR 回放: %w", err)
+		}
+		return nil
+	}, nil
+}
+
+func (t *EdgeTabPage) ExpectDownload(ctx context.Context, action func() error) (*DownloadResult, error) {
+	result, err := t.session.downloads.expect(ctx, t.id, action)
+	return result, t.fail("ExpectDownload", err)
+}
+
+func (t *EdgeTabPage) UploadFiles(ctx context.Context, selector string, paths ...string) error {
+	return t.fail("UploadFiles", set_input_files(ctx, t.page, selector, paths))
+}
+
+func (t *EdgeTabPage) UploadFileData(ctx context.Context, selector string, files ...UploadFile) error {
+	items, err := load_upload_files(files)
+	if err != nil {
+		return err
+	}
+	return t.fail("UploadFileData", set_input_files(ctx, t.page, selector, items))
+}
+
+func (t *EdgeTabPage) DropFiles(ctx context.Context, selector string, files ...UploadFile) error {
+	return t.fail("DropFiles", drop_files(ctx, t.page, selector, files))
+}
+
+// SetDialogPolicy 设置标签页的对话框策略，优先于浏览器策略
+func (t *EdgeTabPage) SetDialogPolicy(policy DialogPolicy) {
+	t.dialogs.setPolicy(&policy)
+}
+
+// ClearDialogPolicy 清除标签页的对话框策略，改用浏览器策略
+func (t *EdgeTabPage) ClearDialogPolicy() {
+	t.dialogs.setPolicy(nil)
+}
+
+func (t *EdgeTabPage) Dialogs() []DialogRecord {
+	return t.dialogs.list()
+}
+
+func (t *EdgeTabPage) Screenshot(ctx context.Context, opts ScreenshotOptions) ([]byte, error) {
+	data, err := take_screenshot(ctx, t.page, opts)
+	return data, t.fail("Screenshot", err)
+}
+
+func (t *EdgeTabPage) PDF(ctx context.Context, opts PDFOptions) ([]byte, error) {
+	cdp, err := t.CDP()
+	if err != nil {
+		return nil, t.fail("PDF", err)
+	}
+	data, err := export_pdf(ctx, cdp, opts)
+	return data, t.fail("PDF", err)
+}
+
+func (t *EdgeTabPage) MHTML(ctx context.Context) ([]byte, error) {
+	cdp, err := t.CDP()
+	if err != nil {
+		return nil, t.fail("MHTML", err)
+	}
+	data, err := export_mhtml(ctx, cdp)
+	return data, t.fail("MHTML", err)
+}
+
+// CDP 返回标签页的 CDP 会话，首次调用或会话被 Detach、被浏览器断开后重新创建，标签页关闭后返回错误
+func (t *EdgeTabPage) CDP() (*CDPSession, error) {
+	t.cdpLocker.Lock()
+	defer t.cdpLocker.Unlock()
+
+	if t.page.IsClosed() {
+		t.cdp = nil
+		return nil, fmt.Errorf("标签页 %s 已关闭，无法使用 CDP 会话", t.id)
+	}
+	if t.cdp == nil {
+		session, err := new_cdp_session(t.page, t.forgetCDP)
+		if err != nil {
+			return nil, err
+		}
+		t.cdp = session
+	}
+	return t.cdp, nil
+}
+
+// forgetCDP 会话断开后丢弃缓存，仅当缓存的仍是该会话时
+func (t *EdgeTabPage) forgetCDP(c *CDPSession) {
+	t.cdpLocker.Lock()
+	defer t.cdpLocker.Unlock()
+
+	if t.cdp == c {
+		t.cdp = nil
+	}
+}
+
+// EmulateNetwork 模拟网络条件，如 NetworkSlow3G、NetworkOffline 或 CustomNetwork
+func (t *EdgeTabPage) EmulateNetwork(ctx context.Context, conditions NetworkConditions) error {
+	cdp, err := t.CDP()
+	if err != nil {
+		return err
+	}
+	return cdp.Network().EmulateConditions(ctx, conditions)
+}
+
+func (t *EdgeTabPage) ClearNetworkEmulation(ctx context.Context) error {
+	cdp, err := t.CDP()
+	if err != nil {
+		return err
+	}
+	return cdp.Network().ClearConditions(ctx)
+}
+
+// SetCPUThrottling 设置 CPU 降速倍数，1 表示取消降速
+func (t *EdgeTabPage) SetCPUThrottling(ctx context.Context, rate float64) error {
+	cdp, err := t.CDP()
+	if err != nil {
+		return err
+	}
+	return cdp.Emulation().SetCPUThrottlingRate(ctx, rate)
+}
+
+// SetTimezone 覆盖已打开页面的时区，如 Asia/Tokyo，为空时取消覆盖
+func (t *EdgeTabPage) SetTimezone(ctx context.Context, timezoneID string) error {
+	cdp, err := t.CDP()
+	if err != nil {
+		return err
+	}
+	return cdp.Emulation().SetTimezone(ctx, timezoneID)
+}
+
+// SetLocale 覆盖已打开页面的语言区域，如 ja-JP，为空时取消覆盖；不影响 Accept-Language 请求头
+func (t *EdgeTabPage) SetLocale(ctx context.Context, locale string) error {
+	cdp, err := t.CDP()
+	if err != nil {
+		return err
+	}
+	return cdp.Emulation().SetLocale(ctx, locale)
+}
+
+// WaitForElement 等待第一个匹配的元素达到指定状态，超时返回 *TimeoutError
+func (t *EdgeTabPage) WaitForElement(ctx context.Context, selector string, state ElementState) (Element, error) {
+	element, err := wait_for_element(ctx, t.page, t, selector, state)
+	return element, t.fail("WaitForElement", err)
+}
+
+// WaitForText 等待页面可见文本中出现 text
+func (t *EdgeTabPage) WaitForText(ctx context.Context, text string) error {
+	return t.fail("WaitForText", wait_for_text(ctx, t.page, text))
+}
+
+// WaitForURL 等待页面 URL 匹配通配符，* 不跨越 /，** 匹配任意字符
+func (t *EdgeTabPage) WaitForURL(ctx context.Context, glob string) error {
+	re, err := glob_to_regexp(glob)
+	if err != nil {
+		return fmt.Errorf("无效的 URL 通配符 %s: %w", glob, err)
+	}
+	return t.fail("WaitForURL", wait_for_url(ctx, t.page, re))
+}
+
+func (t *EdgeTabPage) WaitForURLRegex(ctx context.Context, pattern *regexp.Regexp) error {
+	return t.fail("WaitForURLRegex", wait_for_url(ctx, t.page, pattern))
+}
+
+// WaitForNetworkIdle 等待没有进行中的请求并持续 idle 时长，开始等待前已发出的请求不计入
+func (t *EdgeTabPage) WaitForNetworkIdle(ctx context.Context, idle time.Duration) error {
+	return t.fail("WaitForNetworkIdle", wait_for_network_idle(ctx, t.started, t.requests, idle))
+}
+
+// WaitForFunction 按 polling 间隔执行 JS 表达式直到返回真值，polling 为 0 时每帧执行，返回表达式的值
+func (t *EdgeTabPage) WaitForFunction(ctx context.Context, expression string, polling time.Duration, arg ...any) (any, error) {
+	var argument any
+	if len(arg) > 0 {
+		argument = arg[0]
+	}
+	result, err := wait_for_function(ctx, t.page, expression, polling, argument)
+	return result, t.fail("WaitForFunction", err)
+}
+
+// EnableFailureArtifacts 开启失败现场采集，之后 TabPage 的操作出错时自动保存截图、HTML、URL、控制台消息和 Cookies，
+// 返回的错误为 *ArtifactError
+func (t *EdgeTabPage) EnableFailureArtifacts(opts ArtifactOptions) {
+	t.artifacts.Store(&opts)
+}
+
+func (t *EdgeTabPage) DisableFailureArtifacts() {
+	t.artifacts.Store(nil)
+}
+
+// CaptureArtifacts 立即采集当前页面现场，返回目录或 zip 路径
+func (t *EdgeTabPage) CaptureArtifacts(opts ArtifactOptions) (string, error) {
+	return capture_artifacts(t.page, t.id, nil, t.console.list(), opts)
+}
+
+func (t *EdgeTabPage) ConsoleMessages() []ConsoleMessage {
+	return t.console.list()
+}
+
+// fail 开启失败现场采集时，为错误附加现场保存位置
+func (t *EdgeTabPage) fail(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	opts := t.artifacts.Load()
+	if opts == nil || t.page.IsClosed() {
+		return err
+	}
+	var artifactErr *ArtifactError
+	if errors.As(err, &artifactErr) {
+		return err
+	}
+	bundle, captureErr := capture_artifacts(t.page, t.id, err, t.console.list(), *opts)
+	if captureErr != nil {
+		log.Printf("采集失败现场失败: %v", captureErr)
+	}
+	return &ArtifactError{Op: op, Bundle: bundle, Err: err}
+}
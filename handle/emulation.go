@@ -0,0 +1,79 @@
+package handle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// 常用网络条件，数值与 DevTools 的同名预设一致
+var (
+	NetworkOffline = NetworkConditions{Offline: true}
+	NetworkSlow3G  = NetworkConditions{Latency: 2000 * time.Millisecond, DownloadThroughput: 50000, UploadThroughput: 50000}
+	NetworkFast3G  = NetworkConditions{Latency: 563 * time.Millisecond, DownloadThroughput: 180000, UploadThroughput: 84375}
+)
+
+// CustomNetwork 自定义网络条件，带宽单位为 kbps，0 表示不限制
+func CustomNetwork(latency time.Duration, downloadKbps float64, uploadKbps float64) NetworkConditions {
+	return NetworkConditions{
+		Latency:            latency,
+		DownloadThroughput: downloadKbps * 1000 / 8,
+		UploadThroughput:   uploadKbps * 1000 / 8,
+	}
+}
+
+// emulationState 会话级的网络、CPU、时区和语言模拟设置，会话内新建的标签页创建时自动应用
+type emulationState struct {
+	locker   sync.Mutex
+	network  *NetworkConditions
+	cpuRate  float64
+	timezone string
+	locale   string
+}
+
+func (e *emulationState) setNetwork(conditions *NetworkConditions) {
+	e.locker.Lock()
+	defer e.locker.Unlock()
+	e.network = conditions
+}
+
+func (e *emulationState) setCPURate(rate float64) {
+	e.locker.Lock()
+	defer e.locker.Unlock()
+	e.cpuRate = rate
+}
+
+func (e *emulationState) setTimezone(timezoneID string) {
+	e.locker.Lock()
+	defer e.locker.Unlock()
+	e.timezone = timezoneID
+}
+
+func (e *emulationState) setLocale(locale string) {
+	e.locker.Lock()
+	defer e.locker.Unlock()
+	e.locale = locale
+}
+
+// apply 将当前设置应用到标签页
+func (e *emulationState) apply(ctx context.Context, tab TabPage) error {
+	e.locker.Lock()
+	network, cpuRate, timezone, locale := e.network, e.cpuRate, e.timezone, e.locale
+	e.locker.Unlock()
+
+	var errs []error
+	if network != nil {
+		errs = append(errs, tab.EmulateNetwork(ctx, *network))
+	}
+	if cpuRate > 1 {
+		errs = append(errs, tab.SetCPUThrottling(ctx, cpuRate))
+	}
+	if timezone != "" {
+		errs = append(errs, tab.SetTimezone(ctx, timezone))
+	}
+	if locale != "" {
+		errs = append(errs, tab.SetLocale(ctx, locale))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,27 @@
+package handle
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupported 当前浏览器或运行模式不支持该操作
+var ErrUnsupported = errors.New("当前浏览器不支持该操作")
+
+// ErrElementNotFound 选择器未匹配到任何元素
+var ErrElementNotFound = errors.New("未找到元素")
+
+// ElementError 元素操作失败的错误
+type ElementError struct {
+	Op       string // 失败的操作，如 Click、Fill
+	Selector string // 元素的选择器
+	Err      error  // 原始错误，元素不存在时包含 ErrElementNotFound
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("元素 %s 执行 %s 失败: %v", e.Selector, e.Op, e.Err)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,113 @@
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// callModuleScript 调用通过 Session.RegisterModule 注册的模块函数
+const callModuleScript = `([name, fn, args]) => {
+	const module = (window.__browserHandleModules || {})[name];
+	if (!module) {
+		throw new Error("模块未注册: " + name);
+	}
+	if (typeof module[fn] !== "function") {
+		throw new Error("模块 " + name + " 没有函数 " + fn);
+	}
+	return module[fn](...args);
+}`
+
+// EvaluateAs 执行 JS 表达式并将结果 JSON 解码为 T；多个参数时 expression 必须是函数，参数依次传入
+func EvaluateAs[T any](ctx context.Context, tab TabPage, expression string, args ...any) (T, error) {
+	var v T
+	err := tab.EvaluateInto(ctx, &v, expression, args...)
+	return v, err
+}
+
+// CallAs 调用已注册模块中的函数并将结果 JSON 解码为 T
+func CallAs[T any](ctx context.Context, tab TabPage, module string, fn string, args ...any) (T, error) {
+	var v T
+	err := tab.CallModule(ctx, &v, module, fn, args...)
+	return v, err
+}
+
+// module_script 将 CommonJS 风格的模块源码包装为注册到 window 的脚本
+func module_script(name string, source string) string {
+	key, _ := json.Marshal(name)
+	return fmt.Sprintf(`(() => {
+	const module = { exports: {} };
+	const exports = module.exports;
+	(function (module, exports) {
+%s
+	})(module, exports);
+	window.__browserHandleModules = window.__browserHandleModules || {};
+	window.__browserHandleModules[%s] = module.exports;
+})()`, source, key)
+}
+
+// js_value 按 json 标签将 Go 值转换为可传给页面的 map、slice 和基本类型
+func js_value(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("无法序列化 JS 参数: %w", err)
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("无法序列化 JS 参数: %w", err)
+	}
+	return value, nil
+}
+
+// js_values 转换参数列表
+func js_values(args []any) ([]any, error) {
+	values := make([]any, 0, len(args))
+	for _, arg := range args {
+		value, err := js_value(arg)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// decode_js_result 将 Evaluate 的结果 JSON 解码到 out
+func decode_js_result(result any, out any) error {
+	if out == nil {
+		return nil
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("无法解析 JS 返回值: %w", err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("无法解析 JS 返回值: %w", err)
+	}
+	return nil
+}
+
+// evaluate_with_ctx 在协程中执行，ctx 结束时立即返回，页面中的脚本可能仍在运行
+func evaluate_with_ctx(ctx context.Context, evaluate func() (any, error)) (any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	type reply struct {
+		result any
+		err    error
+	}
+	done := make(chan reply, 1)
+	go func() {
+		result, err := evaluate()
+		done <- reply{result, err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-done:
+		return r.result, r.err
+	}
+}
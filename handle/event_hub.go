@@ -0,0 +1,45 @@
+package handle
+
+import (
+	"sync"
+)
+
+// eventHub 向多个订阅者分发同一类 Playwright 事件，只需向 Playwright 注册一次监听器，
+// 避免 RemoveListener 按函数代码指针移除时误删其他闭包
+type eventHub[T any] struct {
+	locker      sync.Mutex
+	next        int
+	subscribers map[int]func(T)
+}
+
+func newEventHub[T any]() *eventHub[T] {
+	return &eventHub[T]{subscribers: make(map[int]func(T))}
+}
+
+// subscribe 添加订阅者，返回取消订阅的函数
+func (h *eventHub[T]) subscribe(fn func(T)) func() {
+	h.locker.Lock()
+	defer h.locker.Unlock()
+
+	id := h.next
+	h.next++
+	h.subscribers[id] = fn
+	return func() {
+		h.locker.Lock()
+		defer h.locker.Unlock()
+		delete(h.subscribers, id)
+	}
+}
+
+func (h *eventHub[T]) dispatch(event T) {
+	h.locker.Lock()
+	subscribers := make([]func(T), 0, len(h.subscribers))
+	for _, fn := range h.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	h.locker.Unlock()
+
+	for _, fn := range subscribers {
+		fn(event)
+	}
+}
@@ -0,0 +1,191 @@
+package handle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// PDFMargin 页边距，支持 px、in、cm、mm 单位，如 "1cm"
+type PDFMargin struct {
+	Top    string
+	Right  string
+	Bottom string
+	Left   string
+}
+
+// PDFOptions PDF 导出选项
+type PDFOptions struct {
+	Format          string    // 纸张规格，如 A4、Letter，优先于 Width/Height，默认 A4
+	Width           string    // 纸张宽度，如 "210mm"
+	Height          string    // 纸张高度
+	Landscape       bool      // 横向
+	Margin          PDFMargin // 页边距
+	HeaderTemplate  string    // 页眉 HTML 模板，可使用 date、title、url、pageNumber、totalPages 类名
+	FooterTemplate  string    // 页脚 HTML 模板
+	PrintBackground bool      // 打印背景色和背景图
+	Scale           float64   // 缩放比例 0.1-2，0 表示 1
+	PageRanges      string    // 页码范围，如 "1-3, 5"
+	Path            string    // 保存路径，可为空
+	Writer          io.Writer // 输出目标，可为空
+}
+
+// pdfPaperSizes 常用纸张规格，单位英寸
+var pdfPaperSizes = map[string][2]float64{
+	"letter":  {8.5, 11},
+	"legal":   {8.5, 14},
+	"tabloid": {11, 17},
+	"ledger":  {17, 11},
+	"a0":      {33.1, 46.8},
+	"a1":      {23.4, 33.1},
+	"a2":      {16.54, 23.4},
+	"a3":      {11.7, 16.54},
+	"a4":      {8.27, 11.7},
+	"a5":      {5.83, 8.27},
+	"a6":      {4.13, 5.83},
+}
+
+// export_pdf 通过 CDP Page.printToPDF 导出 PDF，有头模式下同样可用
+func export_pdf(ctx context.Context, session *CDPSession, opts PDFOptions) ([]byte, error) {
+	params, err := pdf_params(opts)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Data []byte `json:"data"` // base64 编码，由 json 解码
+	}
+	if err := session.SendAs(ctx, "Page.printToPDF", params, &result); err != nil {
+		return nil, fmt.Errorf("导出 PDF 失败: %w", err)
+	}
+	return result.Data, write_export(result.Data, opts.Path, opts.Writer)
+}
+
+// pdf_params 将导出选项转换为 Page.printToPDF 的参数，尺寸统一换算为英寸
+func pdf_params(opts PDFOptions) (map[string]any, error) {
+	params := map[string]any{
+		"landscape":       opts.Landscape,
+		"printBackground": opts.PrintBackground,
+	}
+
+	format := opts.Format
+	if format == "" && opts.Width == "" && opts.Height == "" {
+		format = "A4"
+	}
+	if format != "" {
+		size, ok := pdfPaperSizes[strings.ToLower(format)]
+		if !ok {
+			return nil, fmt.Errorf("未知的纸张规格: %s", format)
+		}
+		params["paperWidth"], params["paperHeight"] = size[0], size[1]
+	} else {
+		for key, value := range map[string]string{"paperWidth": opts.Width, "paperHeight": opts.Height} {
+			if value == "" {
+				continue
+			}
+			inches, err := pdf_inches(value)
+			if err != nil {
+				return nil, err
+			}
+			params[key] = inches
+		}
+	}
+	for key, value := range map[string]string{
+		"marginTop":    opts.Margin.Top,
+		"marginRight":  opts.Margin.Right,
+		"marginBottom": opts.Margin.Bottom,
+		"marginLeft":   opts.Margin.Left,
+	} {
+		// 未指定的页边距为 0，与 Playwright 一致
+		inches, err := pdf_inches(value)
+		if err != nil {
+			return nil, err
+		}
+		params[key] = inches
+	}
+	if opts.HeaderTemplate != "" || opts.FooterTemplate != "" {
+		params["displayHeaderFooter"] = true
+		// 未指定的一侧使用空模板，避免显示 Chromium 默认的页眉页脚
+		params["headerTemplate"] = opts.HeaderTemplate + " "
+		params["footerTemplate"] = opts.FooterTemplate + " "
+	}
+	if opts.Scale > 0 {
+		params["scale"] = opts.Scale
+	}
+	if opts.PageRanges != "" {
+		params["pageRanges"] = opts.PageRanges
+	}
+	return params, nil
+}
+
+// pdf_inches 将 px、in、cm、mm 长度换算为英寸，不带单位时按 px 处理
+func pdf_inches(length string) (float64, error) {
+	value := strings.ToLower(strings.TrimSpace(length))
+	if value == "" {
+		return 0, nil
+	}
+	units := []struct {
+		suffix string
+		per    float64 // 每英寸的数量
+	}{{"px", 96}, {"in", 1}, {"cm", 2.54}, {"mm", 25.4}}
+	per := 96.0
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			value, per = strings.TrimSuffix(value, unit.suffix), unit.per
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("无效的长度: %s", length)
+	}
+	return n / per, nil
+}
+
+// export_mhtml 通过 CDP Page.captureSnapshot 导出 MHTML
+func export_mhtml(ctx context.Context, session *CDPSession) ([]byte, error) {
+	var result struct {
+		Data string `json:"data"`
+	}
+	if err := session.SendAs(ctx, "Page.captureSnapshot", map[string]any{"format": "mhtml"}, &result); err != nil {
+		return nil, fmt.Errorf("导出 MHTML 失败: %w", err)
+	}
+	return []byte(result.Data), nil
+}
+
+func require_chromium(page playwright.Page) error {
+	browser := page.Context().Browser()
+	if browser == nil || browser.BrowserType().Name() != "chromium" {
+		return fmt.Errorf("%w: 仅支持 Chromium 内核", ErrUnsupported)
+	}
+	return nil
+}
+
+func write_export(data []byte, path string, writer io.Writer) error {
+	if path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("无法创建导出目录: %w", err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("无法保存导出文件: %w", err)
+		}
+	}
+	if writer != nil {
+		if _, err := writer.Write(data); err != nil {
+			return fmt.Errorf("无法输出导出内容: %w", err)
+		}
+	}
+	return nil
+}
+
+func optional_string(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return playwright.String(value)
+}
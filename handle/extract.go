@@ -0,0 +1,251 @@
+package handle
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxExtractDepth 结构体嵌套的最大层数，防止自引用类型无限递归
+const maxExtractDepth = 8
+
+// extractScript 按字段描述在页面中一次性读取所有数据
+const extractScript = `(schema) => {
+	const read = (el, field) => {
+		if (!el) return null;
+		if (!field.attr || field.attr === "text") return (el.textContent || "").replace(/\s+/g, " ").trim();
+		if (field.attr === "html") return el.innerHTML;
+		return el.getAttribute(field.attr);
+	};
+	const extract = (scope, fields) => {
+		const out = {};
+		for (const field of fields) {
+			if (field.list) {
+				const items = field.sel ? Array.from(scope.querySelectorAll(field.sel)) : [];
+				out[field.name] = items.map(el => field.fields ? extract(el, field.fields) : read(el, field));
+			} else {
+				const el = field.sel ? scope.querySelector(field.sel) : scope;
+				out[field.name] = field.fields ? (el ? extract(el, field.fields) : null) : read(el, field);
+			}
+		}
+		return out;
+	};
+	return extract(document, schema);
+}`
+
+// extractField 结构体字段的提取规则
+//
+// 标签说明:
+//   - sel: 相对于上级元素的 CSS 选择器，为空时使用上级元素本身；切片字段匹配所有元素
+//   - attr: 读取的属性，text(默认) 为去除多余空白的文本，html 为 innerHTML，其他为同名属性
+//   - parse: int、float、bool，为空时按字段类型转换；int、float 取文本中的第一个数字，忽略货币符号和千分位，int 截断小数部分
+//
+// 没有 sel 和 attr 标签的基本类型字段不提取，没有 sel 标签的结构体字段在上级元素内提取
+type extractField struct {
+	Name   string         `json:"name"`
+	Sel    string         `json:"sel,omitempty"`
+	Attr   string         `json:"attr,omitempty"`
+	List   bool           `json:"list,omitempty"`
+	Fields []extractField `json:"fields,omitempty"`
+	index  int
+	parse  string
+}
+
+// extract_schema 根据结构体类型生成提取规则
+func extract_schema(t reflect.Type, depth int) ([]extractField, error) {
+	if depth > maxExtractDepth {
+		return nil, fmt.Errorf("结构体 %s 嵌套超过 %d 层", t, maxExtractDepth)
+	}
+	fields := make([]extractField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		sel, hasSel := sf.Tag.Lookup("sel")
+		attr, hasAttr := sf.Tag.Lookup("attr")
+		field := extractField{Name: sf.Name, Sel: sel, Attr: attr, index: i, parse: sf.Tag.Get("parse")}
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Slice {
+			if !hasSel || sel == "" {
+				return nil, fmt.Errorf("切片字段 %s.%s 缺少 sel 标签", t, sf.Name)
+			}
+			field.List = true
+			ft = ft.Elem()
+		}
+		switch {
+		case ft.Kind() == reflect.Struct:
+			children, err := extract_schema(ft, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			field.Fields = children
+		case is_extract_scalar(ft.Kind()):
+			if !hasSel && !hasAttr {
+				continue
+			}
+		default:
+			if !hasSel && !hasAttr {
+				continue
+			}
+			return nil, fmt.Errorf("字段 %s.%s 的类型 %s 不支持提取", t, sf.Name, sf.Type)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func is_extract_scalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// assign_extracted 将页面返回的数据按规则写入结构体
+func assign_extracted(v reflect.Value, fields []extractField, data map[string]any) error {
+	for _, field := range fields {
+		target := v.Field(field.index)
+		raw := data[field.Name]
+		if !field.List {
+			if err := assign_extracted_value(target, field, raw); err != nil {
+				return err
+			}
+			continue
+		}
+		items, _ := raw.([]any)
+		slice := reflect.MakeSlice(target.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := assign_extracted_value(slice.Index(i), field, item); err != nil {
+				return err
+			}
+		}
+		target.Set(slice)
+	}
+	return nil
+}
+
+func assign_extracted_value(target reflect.Value, field extractField, raw any) error {
+	if field.Fields != nil {
+		data, ok := raw.(map[string]any)
+		if !ok {
+			return nil
+		}
+		return assign_extracted(target, field.Fields, data)
+	}
+	if raw == nil {
+		// 元素不存在时保留零值
+		return nil
+	}
+	text, _ := raw.(string)
+	if err := set_extracted_scalar(target, field.parse, text); err != nil {
+		return fmt.Errorf("字段 %s: %w", field.Name, err)
+	}
+	return nil
+}
+
+// numberPattern 匹配文本中的数字，支持千分位逗号；紧跟在字母或数字后的 - 视为连字符而不是负号
+var numberPattern = regexp.MustCompile(`(?:(?:^|[^\p{L}\p{N}.])(-))?((?:\d{1,3}(?:,\d{3})+|\d+)(?:\.\d+)?|\.\d+)`)
+
+// first_number 返回文本中第一个数字，去掉千分位逗号，如 "¥1,299.00 - ¥1,599.00" 返回 "1299.00"
+func first_number(text string) (string, bool) {
+	match := numberPattern.FindStringSubmatch(text)
+	if match == nil {
+		return "", false
+	}
+	return match[1] + strings.ReplaceAll(match[2], ",", ""), true
+}
+
+func set_extracted_scalar(target reflect.Value, parse string, text string) error {
+	kind := target.Kind()
+	if parse == "" {
+		switch kind {
+		case reflect.Bool:
+			parse = "bool"
+		case reflect.Float32, reflect.Float64:
+			parse = "float"
+		case reflect.String:
+		default:
+			parse = "int"
+		}
+	}
+
+	switch parse {
+	case "":
+		target.SetString(text)
+	case "bool":
+		if kind != reflect.Bool {
+			return fmt.Errorf("parse:\"bool\" 需要 bool 类型")
+		}
+		// 元素存在即为 true，除非文本明确为 false
+		value, err := strconv.ParseBool(strings.TrimSpace(text))
+		target.SetBool(err != nil || value)
+	case "float":
+		number, ok := first_number(text)
+		if !ok {
+			return nil
+		}
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return fmt.Errorf("无法将 %q 解析为数字: %w", text, err)
+		}
+		switch kind {
+		case reflect.Float32, reflect.Float64:
+			target.SetFloat(value)
+		case reflect.String:
+			target.SetString(strconv.FormatFloat(value, 'f', -1, 64))
+		default:
+			return fmt.Errorf("parse:\"float\" 需要浮点数类型")
+		}
+	case "int":
+		number, ok := first_number(text)
+		if !ok {
+			return nil
+		}
+		// 小数部分直接截断
+		number, _, _ = strings.Cut(number, ".")
+		if number == "" || number == "-" {
+			number += "0"
+		}
+		value, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return fmt.Errorf("无法将 %q 解析为整数: %w", text, err)
+		}
+		switch kind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			target.SetInt(value)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if value < 0 {
+				return fmt.Errorf("无法将负数 %q 写入无符号整数", text)
+			}
+			target.SetUint(uint64(value))
+		case reflect.String:
+			target.SetString(strconv.FormatInt(value, 10))
+		default:
+			return fmt.Errorf("parse:\"int\" 需要整数类型")
+		}
+	default:
+		return fmt.Errorf("未知的 parse 标签: %s", parse)
+	}
+	return nil
+}
+
+// extract_target 校验 out 必须是非空的结构体指针，返回结构体值及其提取规则
+func extract_target(out any) (reflect.Value, []extractField, error) {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, nil, fmt.Errorf("Extract 需要非空的结构体指针，实际为 %T", out)
+	}
+	fields, err := extract_schema(v.Elem().Type(), 0)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	return v.Elem(), fields, nil
+}
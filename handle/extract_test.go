@@ -0,0 +1,57 @@
+package handle
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetExtractedScalar(t *testing.T) {
+	cases := []struct {
+		name  string
+		out   any // 指向目标字段类型的指针
+		parse string
+		text  string
+		want  any
+	}{
+		{"字符串原样保存", new(string), "", " 标题 ", " 标题 "},
+		{"价格区间取第一个数字", new(float64), "", "¥12.99 - ¥15.99", 12.99},
+		{"千分位", new(float64), "float", "$1,299.50", 1299.5},
+		{"负数", new(float64), "", "变化: -3.5%", -3.5},
+		{"连字符不是负号", new(int), "", "Item-3 $5", 3},
+		{"整数截断小数", new(int), "", "共 12.8 件", 12},
+		{"只有小数部分", new(int), "", ".5", 0},
+		{"无符号整数", new(uint), "", "库存 42", uint(42)},
+		{"整数写入字符串", new(string), "int", "第 7 页", "7"},
+		{"浮点数写入字符串", new(string), "float", "评分 4.50", "4.5"},
+		{"没有数字时保持零值", new(float64), "", "暂无报价", 0.0},
+		{"存在即为 true", new(bool), "", "有货", true},
+		{"明确为 false", new(bool), "", " false ", false},
+	}
+	for _, c := range cases {
+		target := reflect.ValueOf(c.out).Elem()
+		if err := set_extracted_scalar(target, c.parse, c.text); err != nil {
+			t.Errorf("%s: 解析 %q 失败: %v", c.name, c.text, err)
+			continue
+		}
+		if got := target.Interface(); got != c.want {
+			t.Errorf("%s: 解析 %q 得到 %v，期望 %v", c.name, c.text, got, c.want)
+		}
+	}
+
+	errCases := []struct {
+		name  string
+		out   any
+		parse string
+		text  string
+	}{
+		{"负数写入无符号整数", new(uint), "", "-1"},
+		{"bool 标签用于非 bool 字段", new(string), "bool", "true"},
+		{"float 标签用于整数字段", new(int), "float", "1.5"},
+		{"未知的 parse 标签", new(string), "date", "2024-01-01"},
+	}
+	for _, c := range errCases {
+		if err := set_extracted_scalar(reflect.ValueOf(c.out).Elem(), c.parse, c.text); err == nil {
+			t.Errorf("%s: 应返回错误", c.name)
+		}
+	}
+}
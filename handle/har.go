@@ -0,0 +1,391 @@
+package handle
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// HARContentMode 响应体的记录方式
+type HARContentMode string
+
+const (
+	HARContentOmit  HARContentMode = "omit"  // 不记录响应体，文件无法用于回放
+	HARContentEmbed HARContentMode = "embed" // 响应体内嵌到 HAR 文件，二进制内容使用 base64
+)
+
+// harHTTPVersion Playwright 不提供响应的协议版本，按 HAR 规范无法获取时记为 unknown
+const harHTTPVersion = "unknown"
+
+// HAROptions 录制选项
+type HAROptions struct {
+	Content HARContentMode // 默认 HARContentEmbed
+	Matcher RouteMatcher   // 只录制匹配的请求，为空时录制全部
+}
+
+// HARReplayOptions 回放选项
+type HARReplayOptions struct {
+	URLGlob  string // 只回放匹配的 URL，* 不跨越 /，** 匹配任意字符，为空时回放全部
+	Fallback bool   // HAR 中找不到的请求是否放行到网络，默认中止
+}
+
+type harLog struct {
+	Log harLogBody `json:"log"`
+}
+
+type harLogBody struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	ResourceType    string      `json:"_resourceType,omitempty"`
+	FailureText     string      `json:"_failureText,omitempty"`
+	started         time.Time
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	SSL     float64 `json:"ssl"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// HARRecorder 将请求记录为 HAR 1.2 文件，Stop 时写入磁盘
+type HARRecorder struct {
+	path        string
+	content     HARContentMode
+	match       func(req *RouteRequest) bool
+	locker      sync.Mutex
+	entries     []harEntry
+	pending     sync.WaitGroup
+	stopped     bool
+	unsubscribe func()
+}
+
+func new_har_recorder(hub *eventHub[playwright.Request], path string, opts HAROptions) (*HARRecorder, error) {
+	match, err := compile_route_matcher(opts.Matcher)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Content == "" {
+		opts.Content = HARContentEmbed
+	}
+	if opts.Content != HARContentOmit && opts.Content != HARContentEmbed {
+		return nil, fmt.Errorf("未知的 HAR 内容模式: %s", opts.Content)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("无法创建 HAR 目录: %w", err)
+	}
+	recorder := &HARRecorder{
+		path:    path,
+		content: opts.Content,
+		match:   match,
+		entries: make([]harEntry, 0),
+	}
+	recorder.unsubscribe = hub.subscribe(recorder.onRequestDone)
+	return recorder, nil
+}
+
+func (r *HARRecorder) onRequestDone(request playwright.Request) {
+	if !r.match(new_route_request(request)) {
+		return
+	}
+
+	r.locker.Lock()
+	if r.stopped {
+		r.locker.Unlock()
+		return
+	}
+	r.pending.Add(1)
+	r.locker.Unlock()
+
+	// 读取响应需要与 Playwright 通信，不能在事件回调中同步执行
+	go func() {
+		defer r.pending.Done()
+		entry := r.buildEntry(request)
+
+		r.locker.Lock()
+		defer r.locker.Unlock()
+		r.entries = append(r.entries, entry)
+	}()
+}
+
+func (r *HARRecorder) buildEntry(request playwright.Request) harEntry {
+	timing := request.Timing()
+	started := time.Now()
+	if timing != nil && timing.StartTime > 0 {
+		started = time.UnixMilli(int64(timing.StartTime))
+	}
+
+	entry := harEntry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		started:         started,
+		ResourceType:    request.ResourceType(),
+		Request: harRequest{
+			Method:      request.Method(),
+			URL:         request.URL(),
+			HTTPVersion: harHTTPVersion,
+			Cookies:     []harNameValue{},
+			QueryString: har_query_string(request.URL()),
+			HeadersSize: -1,
+			BodySize:    0,
+		},
+		Response: harResponse{
+			HTTPVersion: harHTTPVersion,
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: har_timings(timing),
+	}
+	for _, t := range []float64{entry.Timings.Blocked, entry.Timings.DNS, entry.Timings.Connect, entry.Timings.Send, entry.Timings.Wait, entry.Timings.Receive} {
+		if t > 0 {
+			entry.Time += t
+		}
+	}
+
+	headers, err := request.AllHeaders()
+	if err != nil {
+		headers = request.Headers()
+	}
+	entry.Request.Headers = har_headers(headers)
+	if body, err := request.PostDataBuffer(); err == nil && len(body) > 0 {
+		entry.Request.BodySize = len(body)
+		entry.Request.PostData = &harPostData{MimeType: headers["content-type"], Text: string(body)}
+	}
+
+	if failure := request.Failure(); failure != nil {
+		entry.FailureText = failure.Error()
+		return entry
+	}
+
+	response, err := request.Response()
+	if err != nil || response == nil {
+		return entry
+	}
+	responseHeaders, err := response.AllHeaders()
+	if err != nil {
+		responseHeaders = response.Headers()
+	}
+	entry.Response.Status = response.Status()
+	entry.Response.StatusText = response.StatusText()
+	entry.Response.Headers = har_headers(responseHeaders)
+	entry.Response.RedirectURL = responseHeaders["location"]
+	entry.Response.Content.MimeType = responseHeaders["content-type"]
+	if entry.Response.Status >= 300 && entry.Response.Status < 400 {
+		return entry
+	}
+
+	body, err := response.Body()
+	if err != nil {
+		return entry
+	}
+	entry.Response.BodySize = len(body)
+	entry.Response.Content.Size = len(body)
+	if r.content == HARContentEmbed {
+		if utf8.Valid(body) {
+			entry.Response.Content.Text = string(body)
+		} else {
+			entry.Response.Content.Text = base64.StdEncoding.EncodeToString(body)
+			entry.Response.Content.Encoding = "base64"
+		}
+	}
+	return entry
+}
+
+// Entries 返回目前已录制的请求数
+func (r *HARRecorder) Entries() int {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+	return len(r.entries)
+}
+
+// Stop 停止录制并写入 HAR 文件，可重复调用，每次都会重写文件
+func (r *HARRecorder) Stop() error {
+	r.locker.Lock()
+	if !r.stopped {
+		r.stopped = true
+		r.unsubscribe()
+	}
+	r.locker.Unlock()
+	r.pending.Wait()
+
+	r.locker.Lock()
+	entries := append([]harEntry(nil), r.entries...)
+	r.locker.Unlock()
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].started.Before(entries[j].started) })
+
+	data, err := json.MarshalIndent(harLog{Log: harLogBody{
+		Version: "1.2",
+		Creator: harCreator{Name: "go-browser-handle", Version: "1.0"},
+		Entries: entries,
+	}}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("无法序列化 HAR: %w", err)
+	}
+	if err := os.WriteFile(r.path, data, 0644); err != nil {
+		return fmt.Errorf("无法写入 HAR 文件: %w", err)
+	}
+	log.Printf("HAR 已保存: %s, 共 %d 条请求", r.path, len(entries))
+	return nil
+}
+
+func har_headers(headers map[string]string) []harNameValue {
+	items := make([]harNameValue, 0, len(headers))
+	for name, value := range headers {
+		items = append(items, harNameValue{Name: name, Value: value})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items
+}
+
+func har_query_string(rawURL string) []harNameValue {
+	items := make([]harNameValue, 0)
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return items
+	}
+	for name, values := range u.Query() {
+		for _, value := range values {
+			items = append(items, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items
+}
+
+// har_timings 将 Playwright 的相对时间点换算为 HAR 的分段耗时，无法获取的阶段记为 -1
+func har_timings(timing *playwright.RequestTiming) harTimings {
+	result := harTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1, Send: 0, Wait: -1, Receive: -1}
+	if timing == nil {
+		return result
+	}
+	span := func(start, end float64) float64 {
+		if start < 0 || end < 0 || end < start {
+			return -1
+		}
+		return end - start
+	}
+	result.DNS = span(timing.DomainLookupStart, timing.DomainLookupEnd)
+	result.Connect = span(timing.ConnectStart, timing.ConnectEnd)
+	result.SSL = span(timing.SecureConnectionStart, timing.ConnectEnd)
+	result.Wait = span(timing.RequestStart, timing.ResponseStart)
+	result.Receive = span(timing.ResponseStart, timing.ResponseEnd)
+	if result.Wait < 0 {
+		result.Wait = 0
+	}
+	if result.Receive < 0 {
+		result.Receive = 0
+	}
+	return result
+}
+
+// har_check_replay 回放前检查 HAR 文件，响应体缺失(例如以 HARContentOmit 录制)时记录警告
+func har_check_replay(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		// 交由 RouteFromHAR 返回错误
+		return
+	}
+	var har struct {
+		Log struct {
+			Entries []struct {
+				Response struct {
+					Content struct {
+						Size int    `json:"size"`
+						Text string `json:"text"`
+						File string `json:"_file"`
+					} `json:"content"`
+				} `json:"response"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.Unmarshal(data, &har); err != nil {
+		return
+	}
+	missing := 0
+	for _, entry := range har.Log.Entries {
+		content := entry.Response.Content
+		if content.Size > 0 && content.Text == "" && content.File == "" {
+			missing++
+		}
+	}
+	if missing > 0 {
+		log.Printf("HAR 文件 %s 中有 %d 条响应没有记录响应体，回放时将返回空内容，录制时请使用 HARContentEmbed", path, missing)
+	}
+}
+
+func har_not_found(opts HARReplayOptions) *playwright.HarNotFound {
+	if opts.Fallback {
+		return playwright.HarNotFoundFallback
+	}
+	return playwright.HarNotFoundAbort
+}
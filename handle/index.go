@@ -24,6 +24,16 @@ func Edge() *EdgeBrowserInstance {
 }
 
 func (m *EdgeBrowserInstance) Listen(debugPort int) (Browser, error) {
+	return m.ListenWithOptions(debugPort, LaunchOptions{})
+}
+
+// ListenWithOptions 连接或启动 Edge，opts 仅在需要启动新的 Edge 实例时生效
+func (m *EdgeBrowserInstance) ListenWithOptions(debugPort int, opts LaunchOptions) (Browser, error) {
+	if opts.Proxy != nil {
+		if err := opts.Proxy.validate(); err != nil {
+			return nil, err
+		}
+	}
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
@@ -38,7 +48,7 @@ func (m *EdgeBrowserInstance) Listen(debugPort int) (Browser, error) {
 	browsers := find_installed_browsers()
 	if path, ok := browsers["edge"]; ok {
 		log.Printf("Found Edge browser at: %s\n", path)
-		browser, err := newEdgeBrowser(path, debugPort)
+		browser, err := newEdgeBrowser(path, debugPort, opts)
 		if err != nil {
 			return nil, fmt.Errorf("failed to start Edge browser: %w", err)
 		}
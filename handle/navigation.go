@@ -0,0 +1,227 @@
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// WaitUntil 导航完成的判定时机
+type WaitUntil string
+
+const (
+	WaitCommit           WaitUntil = "commit"           // 收到响应并开始加载文档
+	WaitDOMContentLoaded WaitUntil = "domcontentloaded" // DOMContentLoaded 事件
+	WaitLoad             WaitUntil = "load"             // load 事件
+	WaitNetworkIdle      WaitUntil = "networkidle"      // 至少 500ms 没有网络请求
+)
+
+// NavigateOptions 导航选项
+type NavigateOptions struct {
+	WaitUntil WaitUntil         // 默认 WaitLoad
+	Selector  string            // 达到 WaitUntil 后再等待该元素可见，可为空
+	Referer   string            // Referer 请求头
+	Headers   map[string]string // 仅附加到本次导航的主文档请求(含重定向)，导航结束后不再生效
+}
+
+// RedirectHop 重定向链中的一跳
+type RedirectHop struct {
+	URL    string
+	Status int
+}
+
+// NavigationTimings 导航耗时，浏览器未提供的阶段为 0
+type NavigationTimings struct {
+	DNS              time.Duration // 域名解析
+	Connect          time.Duration // 建立连接，包含 TLS 握手
+	TTFB             time.Duration // 发出请求到收到响应首字节
+	DOMContentLoaded time.Duration // 导航开始到 DOMContentLoaded 完成
+	Load             time.Duration // 导航开始到 load 完成
+	Total            time.Duration // Navigate 的总耗时
+}
+
+// NavigationResult 导航结果，同文档导航(如只改变 hash)时 Status 为 0
+type NavigationResult struct {
+	URL        string            // 最终地址
+	Status     int               // 最终响应的状态码
+	StatusText string            // 最终响应的状态描述
+	Headers    map[string]string // 最终响应的响应头，名称为小写
+	Redirects  []RedirectHop     // 重定向链，按发生顺序排列，不含最终响应
+	Timings    NavigationTimings // 耗时
+}
+
+// OK 最终响应的状态码是否为 2xx
+func (r *NavigationResult) OK() bool {
+	return r.Status >= 200 && r.Status < 300
+}
+
+// Redirected 导航过程中是否发生了重定向
+func (r *NavigationResult) Redirected() bool {
+	return len(r.Redirects) > 0
+}
+
+// route_navigation_headers 用临时路由只为本次导航的主文档请求(含重定向)附加请求头，
+// 不影响页面已有的 SetExtraHTTPHeaders 设置和其他请求；返回的函数注销该路由
+func route_navigation_headers(page playwright.Page, headers map[string]string) (func(), error) {
+	pattern, err := unique_route_pattern("")
+	if err != nil {
+		return nil, err
+	}
+	handler := func(route playwright.Route) {
+		request := route.Request()
+		if !request.IsNavigationRequest() || request.Frame() != page.MainFrame() {
+			if err := route.Fallback(); err != nil {
+				log.Printf("放行请求失败: %v", err)
+			}
+			return
+		}
+		merged := request.Headers()
+		for k, v := range headers {
+			merged[strings.ToLower(k)] = v
+		}
+		if err := route.Fallback(playwright.RouteFallbackOptions{Headers: merged}); err != nil {
+			log.Printf("附加请求头失败: %v", err)
+		}
+	}
+	if err := page.Route(pattern, handler); err != nil {
+		return nil, fmt.Errorf("无法设置请求头: %w", err)
+	}
+	return func() {
+		if err := page.Unroute(pattern); err != nil {
+			log.Printf("注销请求头路由失败: %v", err)
+		}
+	}, nil
+}
+
+func navigate(ctx context.Context, page playwright.Page, debugPort int, url string, opts NavigateOptions) (*NavigationResult, error) {
+	started := time.Now()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	waitUntil := opts.WaitUntil
+	if waitUntil == "" {
+		waitUntil = WaitLoad
+	}
+	// 先等到 DOMContentLoaded 以便尽早注入脚本，再等待更晚的状态
+	gotoState := playwright.WaitUntilStateDomcontentloaded
+	var loadState *playwright.LoadState
+	switch waitUntil {
+	case WaitCommit:
+		gotoState = playwright.WaitUntilStateCommit
+	case WaitDOMContentLoaded:
+	case WaitLoad:
+		loadState = playwright.LoadStateLoad
+	case WaitNetworkIdle:
+		loadState = playwright.LoadStateNetworkidle
+	default:
+		return nil, fmt.Errorf("未知的导航完成时机: %s", waitUntil)
+	}
+
+	if len(opts.Headers) > 0 {
+		unroute, err := route_navigation_headers(page, opts.Headers)
+		if err != nil {
+			return nil, err
+		}
+		defer unroute()
+	}
+
+	response, err := page.Goto(url, playwright.PageGotoOptions{
+		WaitUntil: gotoState,
+		Referer:   optional_string(opts.Referer),
+		Timeout:   ctx_timeout(ctx),
+	})
+	if err != nil {
+		if errors.Is(err, playwright.ErrTimeout) {
+			return nil, wait_error("打开 "+url, started, err)
+		}
+		return nil, fmt.Errorf("无法访问网站: %w", err)
+	}
+
+	block_debug_port_detector(page, debugPort)
+
+	if loadState != nil {
+		err = wait_with_ctx(ctx, "页面加载", func() error {
+			return page.WaitForLoadState(playwright.PageWaitForLoadStateOptions{State: loadState, Timeout: ctx_timeout(ctx)})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opts.Selector != "" {
+		if _, err := wait_for_element(ctx, page, nil, opts.Selector, ElementVisible); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &NavigationResult{URL: page.URL()}
+	if response != nil {
+		result.Status = response.Status()
+		result.StatusText = response.StatusText()
+		result.Headers = response.Headers()
+		result.Redirects = redirect_chain(response.Request())
+		if timing := response.Request().Timing(); timing != nil {
+			result.Timings.DNS = ms_duration(timing.DomainLookupEnd - timing.DomainLookupStart)
+			result.Timings.Connect = ms_duration(timing.ConnectEnd - timing.ConnectStart)
+			result.Timings.TTFB = ms_duration(timing.ResponseStart - timing.RequestStart)
+		}
+	}
+	if waitUntil != WaitCommit {
+		fill_document_timings(page, &result.Timings)
+	}
+	result.Timings.Total = time.Since(started)
+	log.Printf("已成功访问网站: %s", url)
+	return result, nil
+}
+
+// redirect_chain 沿 RedirectedFrom 回溯重定向链
+func redirect_chain(request playwright.Request) []RedirectHop {
+	hops := make([]RedirectHop, 0)
+	for previous := request.RedirectedFrom(); previous != nil; previous = previous.RedirectedFrom() {
+		hop := RedirectHop{URL: previous.URL()}
+		if response, err := previous.Response(); err == nil && response != nil {
+			hop.Status = response.Status()
+		}
+		hops = append(hops, hop)
+	}
+	slices.Reverse(hops)
+	return hops
+}
+
+// fill_document_timings 从 Navigation Timing API 读取文档加载耗时
+func fill_document_timings(page playwright.Page, timings *NavigationTimings) {
+	value, err := page.Evaluate(`() => {
+		const entry = performance.getEntriesByType("navigation")[0];
+		return entry ? { dcl: entry.domContentLoadedEventEnd, load: entry.loadEventEnd } : null;
+	}`)
+	if err != nil || value == nil {
+		return
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	var entry struct {
+		DCL  float64 `json:"dcl"`
+		Load float64 `json:"load"`
+	}
+	if json.Unmarshal(data, &entry) == nil {
+		timings.DOMContentLoaded = ms_duration(entry.DCL)
+		timings.Load = ms_duration(entry.Load)
+	}
+}
+
+// ms_duration 将毫秒数转换为 time.Duration，负数表示不可用，返回 0
+func ms_duration(ms float64) time.Duration {
+	if ms <= 0 {
+		return 0
+	}
+	return time.Duration(ms * float64(time.Millisecond))
+}
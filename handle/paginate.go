@@ -0,0 +1,374 @@
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PaginateMode 翻页方式
+type PaginateMode string
+
+const (
+	PaginateNext   PaginateMode = "next"   // 点击 NextSelector 匹配的下一页按钮
+	PaginateURL    PaginateMode = "url"    // 按 URLTemplate 依次打开每一页
+	PaginateScroll PaginateMode = "scroll" // 滚动到页面底部加载更多
+)
+
+// nextStateScript 检查下一页按钮: missing 不存在，disabled 已禁用，enabled 可点击
+const nextStateScript = `(selector) => {
+	const el = document.querySelector(selector);
+	if (!el) return "missing";
+	const disabled = el.disabled === true ||
+		el.getAttribute("aria-disabled") === "true" ||
+		el.classList.contains("disabled");
+	return disabled ? "disabled" : "enabled";
+}`
+
+// scrollBottomScript 滚动到页面底部
+const scrollBottomScript = `() => window.scrollTo(0, document.documentElement.scrollHeight)`
+
+// PageCursor 翻页进度，通过 PaginateOptions.Resume 从该页之后继续
+type PageCursor struct {
+	Page  int    // 已完成的页序号，从 1 开始
+	URL   string // 恢复时打开的地址，滚动模式下为起始地址
+	Steps int    // 打开 URL 后还需翻页几次才能回到该页；点击翻页不改变地址或滚动加载时大于 0
+}
+
+// PageItem 翻页提取到的一个项目
+type PageItem[T any] struct {
+	Item   T
+	Page   int        // 项目所在的页序号
+	Cursor PageCursor // 项目所在页的游标，从该游标恢复时从下一页开始
+}
+
+// PaginateOptions 翻页选项
+type PaginateOptions[T any] struct {
+	Mode          PaginateMode
+	Extract       func(ctx context.Context, tab TabPage) ([]T, error) // 提取当前页的所有项目，滚动模式下包含之前加载的项目
+	Key           func(item T) string                                 // 去重键，默认使用项目的 JSON 序列化
+	NextSelector  string                                              // PaginateNext 模式的下一页按钮 CSS 选择器，不存在或已禁用时结束
+	URLTemplate   string                                              // PaginateURL 模式的地址模板，{page} 替换为页码
+	FirstPage     int                                                 // URLTemplate 的起始页码，默认 1
+	MaxPages      int                                                 // 最多处理的页数，0 表示不限
+	StaleLimit    int                                                 // 连续多少页没有新项目时结束，默认 1
+	Until         func(page int, items []T) bool                      // 每页的新项目发送后调用，返回 true 时结束
+	SettleTimeout time.Duration                                       // 点击或滚动后等待新项目出现的时间，默认 10s
+	PollInterval  time.Duration                                       // 等待新项目时的提取间隔，默认 300ms
+	Resume        *PageCursor                                         // 从游标之后继续，可为空
+	Buffer        int                                                 // 项目通道的缓冲大小
+}
+
+// Paginator 翻页提取器，项目通过 Items 通道发送，通道关闭后 Err 返回结束原因
+type Paginator[T any] struct {
+	tab    TabPage
+	opts   PaginateOptions[T]
+	items  chan PageItem[T]
+	seen   map[string]struct{}
+	mu     sync.Mutex
+	cursor PageCursor
+	err    error
+}
+
+// Paginate 在 tab 上开始翻页提取，直到没有下一页、没有新项目、达到 MaxPages、Until 返回 true 或 ctx 结束
+func Paginate[T any](ctx context.Context, tab TabPage, opts PaginateOptions[T]) (*Paginator[T], error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	if opts.Key == nil {
+		opts.Key = func(item T) string {
+			data, _ := json.Marshal(item)
+			return string(data)
+		}
+	}
+	if opts.FirstPage <= 0 {
+		opts.FirstPage = 1
+	}
+	if opts.StaleLimit <= 0 {
+		opts.StaleLimit = 1
+	}
+	if opts.SettleTimeout <= 0 {
+		opts.SettleTimeout = 10 * time.Second
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 300 * time.Millisecond
+	}
+
+	p := &Paginator[T]{
+		tab:   tab,
+		opts:  opts,
+		items: make(chan PageItem[T], max(opts.Buffer, 0)),
+		seen:  make(map[string]struct{}),
+	}
+	if opts.Resume != nil {
+		p.cursor = *opts.Resume
+	}
+	go func() {
+		defer close(p.items)
+		err := p.run(ctx)
+		p.mu.Lock()
+		p.err = err
+		p.mu.Unlock()
+	}()
+	return p, nil
+}
+
+func (o *PaginateOptions[T]) validate() error {
+	if o.Extract == nil {
+		return fmt.Errorf("翻页缺少 Extract 函数")
+	}
+	switch o.Mode {
+	case PaginateNext:
+		if o.NextSelector == "" {
+			return fmt.Errorf("翻页模式 %s 缺少 NextSelector", o.Mode)
+		}
+	case PaginateURL:
+		if !strings.Contains(o.URLTemplate, "{page}") {
+			return fmt.Errorf("翻页模式 %s 的 URLTemplate 缺少 {page}", o.Mode)
+		}
+	case PaginateScroll:
+	default:
+		return fmt.Errorf("未知的翻页模式: %s", o.Mode)
+	}
+	return nil
+}
+
+// Items 项目通道，翻页结束后关闭
+func (p *Paginator[T]) Items() <-chan PageItem[T] {
+	return p.items
+}
+
+// Cursor 最后一个项目已全部发送的页的游标
+func (p *Paginator[T]) Cursor() PageCursor {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cursor
+}
+
+// Err Items 通道关闭后返回翻页失败的原因，正常结束时为 nil
+func (p *Paginator[T]) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+func (p *Paginator[T]) run(ctx context.Context) error {
+	page, err := p.start(ctx)
+	if err != nil {
+		return err
+	}
+	items, err := p.load(ctx, page)
+	if err != nil {
+		return err
+	}
+
+	stale := 0
+	for {
+		fresh := p.fresh(items)
+		cursor := p.next_cursor(page)
+		for _, item := range fresh {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case p.items <- PageItem[T]{Item: item, Page: page, Cursor: cursor}:
+			}
+		}
+		p.mu.Lock()
+		p.cursor = cursor
+		p.mu.Unlock()
+
+		if p.opts.Until != nil && p.opts.Until(page, fresh) {
+			return nil
+		}
+		if len(fresh) == 0 {
+			if stale++; stale >= p.opts.StaleLimit {
+				log.Printf("连续 %d 页没有新项目，结束翻页", stale)
+				return nil
+			}
+		} else {
+			stale = 0
+		}
+		if p.opts.MaxPages > 0 && page >= p.opts.MaxPages {
+			return nil
+		}
+
+		more, err := p.advance(ctx, page+1)
+		if err != nil || !more {
+			return err
+		}
+		page++
+		if items, err = p.load(ctx, page); err != nil {
+			return err
+		}
+	}
+}
+
+// start 打开起始页或恢复到游标之后的位置，返回即将提取的页序号
+func (p *Paginator[T]) start(ctx context.Context) (int, error) {
+	resume := p.opts.Resume
+	if resume == nil {
+		if p.opts.Mode == PaginateURL {
+			return 1, p.navigate(ctx, 1)
+		}
+		return 1, nil
+	}
+
+	page := resume.Page + 1
+	switch p.opts.Mode {
+	case PaginateURL:
+		return page, p.navigate(ctx, page)
+	default:
+		if resume.Steps < 0 || resume.Steps >= resume.Page {
+			return 0, fmt.Errorf("无效的翻页游标: %+v", *resume)
+		}
+		if _, err := p.tab.Navigate(ctx, resume.URL, NavigateOptions{}); err != nil {
+			return 0, err
+		}
+		// 从 URL 对应的页开始重新翻到游标之后，途经的项目只记录不发送
+		for i := resume.Page - resume.Steps; i <= resume.Page; i++ {
+			if err := p.skip(ctx, i); err != nil {
+				return 0, err
+			}
+			more, err := p.advance(ctx, i+1)
+			if err != nil {
+				return 0, err
+			}
+			if !more {
+				return 0, fmt.Errorf("无法从第 %d 页恢复: 第 %d 页没有下一页", resume.Page, i)
+			}
+		}
+	}
+	return page, nil
+}
+
+// next_cursor 第 page 页的游标：点击翻页后地址未变化时沿用上一页的地址并累加 Steps，
+// 滚动模式始终使用起始地址
+func (p *Paginator[T]) next_cursor(page int) PageCursor {
+	p.mu.Lock()
+	prev := p.cursor
+	p.mu.Unlock()
+
+	current := p.tab.URL()
+	switch p.opts.Mode {
+	case PaginateScroll:
+		if prev.URL == "" {
+			prev.URL = current
+		}
+		return PageCursor{Page: page, URL: prev.URL, Steps: page - 1}
+	case PaginateNext:
+		if prev.URL != "" && prev.URL == current && prev.Page == page-1 {
+			return PageCursor{Page: page, URL: prev.URL, Steps: prev.Steps + 1}
+		}
+	}
+	return PageCursor{Page: page, URL: current}
+}
+
+// skip 提取已完成的第 page 页并记录为已发送
+func (p *Paginator[T]) skip(ctx context.Context, page int) error {
+	items, err := p.load(ctx, page)
+	if err != nil {
+		return fmt.Errorf("恢复翻页进度失败: %w", err)
+	}
+	p.fresh(items)
+	return nil
+}
+
+// fresh 过滤已发送过的项目并记录新项目
+func (p *Paginator[T]) fresh(items []T) []T {
+	fresh := make([]T, 0, len(items))
+	for _, item := range items {
+		key := p.opts.Key(item)
+		if _, ok := p.seen[key]; ok {
+			continue
+		}
+		p.seen[key] = struct{}{}
+		fresh = append(fresh, item)
+	}
+	return fresh
+}
+
+// advance 前往第 page 页，没有下一页时返回 false
+func (p *Paginator[T]) advance(ctx context.Context, page int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	switch p.opts.Mode {
+	case PaginateURL:
+		return true, p.navigate(ctx, page)
+	case PaginateScroll:
+		if _, err := p.tab.Evaluate(scrollBottomScript); err != nil {
+			return false, fmt.Errorf("滚动页面失败: %w", err)
+		}
+		return true, nil
+	}
+
+	var state string
+	if err := p.tab.EvaluateInto(ctx, &state, nextStateScript, p.opts.NextSelector); err != nil {
+		return false, fmt.Errorf("无法检查下一页按钮: %w", err)
+	}
+	if state != "enabled" {
+		return false, nil
+	}
+	next := p.tab.QuerySelector(p.opts.NextSelector)
+	if next == nil {
+		return false, nil
+	}
+	if err := next.Click(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// navigate 按地址模板打开第 page 页
+func (p *Paginator[T]) navigate(ctx context.Context, page int) error {
+	number := strconv.Itoa(p.opts.FirstPage + page - 1)
+	url := strings.ReplaceAll(p.opts.URLTemplate, "{page}", number)
+	if _, err := p.tab.Navigate(ctx, url, NavigateOptions{}); err != nil {
+		return fmt.Errorf("打开第 %d 页失败: %w", page, err)
+	}
+	return nil
+}
+
+// load 提取翻页后的项目；点击和滚动模式下轮询直到出现新项目或超过 SettleTimeout，
+// 期间页面跳转导致的提取失败会被忽略
+func (p *Paginator[T]) load(ctx context.Context, page int) ([]T, error) {
+	if p.opts.Mode == PaginateURL {
+		items, err := p.opts.Extract(ctx, p.tab)
+		if err != nil {
+			return nil, fmt.Errorf("提取第 %d 页失败: %w", page, err)
+		}
+		return items, nil
+	}
+
+	deadline := time.Now().Add(p.opts.SettleTimeout)
+	var lastErr error
+	for {
+		items, err := p.opts.Extract(ctx, p.tab)
+		if err == nil {
+			lastErr = nil
+			for _, item := range items {
+				if _, ok := p.seen[p.opts.Key(item)]; !ok {
+					return items, nil
+				}
+			}
+		} else {
+			lastErr = err
+		}
+		if time.Now().After(deadline) {
+			if lastErr != nil {
+				return nil, fmt.Errorf("提取第 %d 页失败: %w", page, lastErr)
+			}
+			return items, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(p.opts.PollInterval):
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package handle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// Permission 浏览器权限名称
+type Permission string
+
+const (
+	PermissionGeolocation    Permission = "geolocation"
+	PermissionNotifications  Permission = "notifications"
+	PermissionClipboardRead  Permission = "clipboard-read"
+	PermissionClipboardWrite Permission = "clipboard-write"
+	PermissionCamera         Permission = "camera"
+	PermissionMicrophone     Permission = "microphone"
+)
+
+// PermissionSetting 权限状态
+type PermissionSetting string
+
+const (
+	PermissionGranted PermissionSetting = "granted"
+	PermissionDenied  PermissionSetting = "denied"
+	PermissionPrompt  PermissionSetting = "prompt" // 恢复为默认的询问状态
+)
+
+// Geolocation 地理位置，Accuracy 单位为米
+type Geolocation struct {
+	Latitude  float64
+	Longitude float64
+	Accuracy  float64
+}
+
+// set_permission 通过浏览器级 CDP 会话设置权限，不依赖打开的标签页；
+// contextID 为空时作用于默认上下文，origin 为空时对所有来源生效
+func set_permission(ctx context.Context, browser playwright.Browser, contextID string, origin string, permission Permission, setting PermissionSetting) error {
+	params := map[string]any{
+		"permission": map[string]any{"name": string(permission)},
+		"setting":    string(setting),
+	}
+	if contextID != "" {
+		params["browserContextId"] = contextID
+	}
+	if origin != "" {
+		params["origin"] = origin
+	}
+	if err := send_browser_cdp(ctx, browser, "Browser.setPermission", params, nil); err != nil {
+		return fmt.Errorf("无法设置权限 %s: %w", permission, err)
+	}
+	return nil
+}
+
+// reset_permissions 将浏览器上下文的所有权限恢复为默认状态，contextID 为空时作用于默认上下文
+func reset_permissions(ctx context.Context, browser playwright.Browser, contextID string) error {
+	params := map[string]any{}
+	if contextID != "" {
+		params["browserContextId"] = contextID
+	}
+	if err := send_browser_cdp(ctx, browser, "Browser.resetPermissions", params, nil); err != nil {
+		return fmt.Errorf("无法重置权限: %w", err)
+	}
+	return nil
+}
+
+// browser_context_ids 返回浏览器中除默认上下文外的所有上下文在 CDP 中的ID
+func browser_context_ids(ctx context.Context, browser playwright.Browser) ([]string, error) {
+	var result struct {
+		BrowserContextIDs []string `json:"browserContextIds"`
+	}
+	if err := send_browser_cdp(ctx, browser, "Target.getBrowserContexts", nil, &result); err != nil {
+		return nil, fmt.Errorf("无法获取浏览器上下文列表: %w", err)
+	}
+	return result.BrowserContextIDs, nil
+}
+
+// send_browser_cdp 通过临时的浏览器级 CDP 会话发送命令，out 为空时忽略返回值
+func send_browser_cdp(ctx context.Context, browser playwright.Browser, method string, params map[string]any, out any) error {
+	session, err := browser.NewBrowserCDPSession()
+	if err != nil {
+		return fmt.Errorf("无法创建 CDP 会话: %w", err)
+	}
+	defer session.Detach()
+
+	cdp := &CDPSession{session: session}
+	if out == nil {
+		_, err = cdp.Send(ctx, method, params)
+		return err
+	}
+	return cdp.SendAs(ctx, method, params, out)
+}
@@ -0,0 +1,159 @@
+package handle
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ProxyConfig 代理配置
+type ProxyConfig struct {
+	Server   string   // 如 http://127.0.0.1:8080、socks5://127.0.0.1:1080，省略协议时为 http
+	Username string   // 仅 HTTP/HTTPS 代理支持认证
+	Password string   // 代理密码
+	Bypass   []string // 不走代理的地址，如 localhost、*.example.com
+}
+
+// LaunchOptions 启动浏览器的选项，连接到已运行的 Edge 时不生效
+type LaunchOptions struct {
+	// Proxy 浏览器级代理，未指定代理的会话都使用它；启动参数无法携带账号密码，
+	// 新建会话通过 Playwright 的上下文代理选项认证，默认会话的标签页通过 CDP Fetch 回应认证质询，
+	// 此时默认会话的每个请求都会被暂停一次再放行
+	Proxy *ProxyConfig
+}
+
+// ProxyProvider 为新建的会话选择代理，返回 nil 表示直连
+type ProxyProvider interface {
+	NextProxy(sessionID string) (*ProxyConfig, error)
+}
+
+// roundRobinProxies 依次轮换的代理列表
+type roundRobinProxies struct {
+	locker  sync.Mutex
+	proxies []ProxyConfig
+	next    int
+}
+
+// RoundRobinProxies 按顺序为每个新会话分配代理
+func RoundRobinProxies(proxies ...ProxyConfig) ProxyProvider {
+	return &roundRobinProxies{proxies: proxies}
+}
+
+func (r *roundRobinProxies) NextProxy(sessionID string) (*ProxyConfig, error) {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+
+	if len(r.proxies) == 0 {
+		return nil, fmt.Errorf("代理列表为空")
+	}
+	proxy := r.proxies[r.next%len(r.proxies)]
+	r.next++
+	return &proxy, nil
+}
+
+// validate 检查代理地址和认证方式
+func (p *ProxyConfig) validate() error {
+	server := p.Server
+	if !strings.Contains(server, "://") {
+		server = "http://" + server
+	}
+	u, err := url.Parse(server)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("代理地址无效: %s", p.Server)
+	}
+	switch u.Scheme {
+	case "http", "https":
+	case "socks5":
+		if p.Username != "" {
+			return fmt.Errorf("%w: Chromium 不支持带认证的 SOCKS5 代理", ErrUnsupported)
+		}
+	default:
+		return fmt.Errorf("不支持的代理协议: %s", u.Scheme)
+	}
+	return nil
+}
+
+// launchArgs 转换为 Edge 的启动参数
+func (p *ProxyConfig) launchArgs() []string {
+	args := []string{"--proxy-server=" + p.Server}
+	if len(p.Bypass) > 0 {
+		args = append(args, "--proxy-bypass-list="+strings.Join(p.Bypass, ";"))
+	}
+	return args
+}
+
+// contextProxy 转换为 Playwright 的上下文代理选项
+func (p *ProxyConfig) contextProxy() *playwright.Proxy {
+	proxy := &playwright.Proxy{Server: p.Server}
+	if len(p.Bypass) > 0 {
+		proxy.Bypass = playwright.String(strings.Join(p.Bypass, ","))
+	}
+	if p.Username != "" {
+		proxy.Username = playwright.String(p.Username)
+		proxy.Password = playwright.String(p.Password)
+	}
+	return proxy
+}
+
+// proxyAuthenticator 通过独立的 CDP 会话回应浏览器级代理的认证质询，
+// 用于无法设置 Playwright 代理选项的默认上下文；与标签页的 CDP() 会话分开，调用方 Detach 不影响认证
+type proxyAuthenticator struct {
+	session  playwright.CDPSession
+	page     playwright.Page
+	username string
+	password string
+	attempts sync.Map // 已提供过账号密码的请求ID
+}
+
+// enable_proxy_auth 为页面开启代理认证，开启后页面的每个请求都会被 Fetch 暂停一次再放行
+func enable_proxy_auth(page playwright.Page, proxy *ProxyConfig) error {
+	session, err := page.Context().NewCDPSession(page)
+	if err != nil {
+		return fmt.Errorf("无法创建代理认证的 CDP 会话: %w", err)
+	}
+	a := &proxyAuthenticator{session: session, page: page, username: proxy.Username, password: proxy.Password}
+	session.On("Fetch.requestPaused", a.onPaused)
+	session.On("Fetch.authRequired", a.onAuth)
+	_, err = session.Send("Fetch.enable", map[string]any{
+		"handleAuthRequests": true,
+		"patterns":           []any{map[string]any{"urlPattern": "*"}},
+	})
+	if err != nil {
+		session.Detach()
+		return fmt.Errorf("无法启用代理认证: %w", err)
+	}
+	return nil
+}
+
+// reply 事件在分发协程中执行，命令需要在新协程中发送；页面关闭后的失败不再记录
+func (a *proxyAuthenticator) reply(method string, params map[string]any) {
+	go func() {
+		if _, err := a.session.Send(method, params); err != nil && !a.page.IsClosed() {
+			log.Printf("代理认证命令 %s 失败: %v", method, err)
+		}
+	}()
+}
+
+func (a *proxyAuthenticator) onPaused(params map[string]any) {
+	a.reply("Fetch.continueRequest", map[string]any{"requestId": params["requestId"]})
+}
+
+// onAuth 只回应代理的质询，网站的 401 质询交给浏览器默认处理
+func (a *proxyAuthenticator) onAuth(params map[string]any) {
+	requestID, _ := params["requestId"].(string)
+	response := map[string]any{"response": "Default"}
+	if challenge, _ := params["authChallenge"].(map[string]any); challenge["source"] == "Proxy" {
+		if _, retried := a.attempts.LoadOrStore(requestID, true); retried {
+			// 同一请求再次质询说明账号密码错误，取消认证避免反复重试
+			response = map[string]any{"response": "CancelAuth"}
+			a.attempts.Delete(requestID)
+		} else {
+			response = map[string]any{"response": "ProvideCredentials", "username": a.username, "password": a.password}
+		}
+	}
+	a.reply("Fetch.continueWithAuth", map[string]any{"requestId": requestID, "authChallengeResponse": response})
+}
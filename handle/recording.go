@@ -0,0 +1,161 @@
+package handle
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// RetentionPolicy 录制文件的保留策略
+type RetentionPolicy string
+
+const (
+	RetainAlways RetentionPolicy = "always" // 会话关闭时总是保存
+	// RetainOnFailure 仅在会话被标记为失败时保存；标签页操作出错不会自动标记，
+	// 任务失败时需由调用方在关闭会话前调用 Session.MarkFailed
+	RetainOnFailure RetentionPolicy = "on-failure"
+)
+
+// TraceOptions Playwright 追踪录制选项，追踪文件可用 playwright show-trace 打开
+type TraceOptions struct {
+	Dir         string          // 保存目录，默认 当前目录/traces
+	Screenshots bool            // 记录截图
+	Snapshots   bool            // 记录 DOM 快照
+	Sources     bool            // 记录源码
+	Retention   RetentionPolicy // 默认 RetainAlways
+}
+
+// VideoOptions 视频录制选项，只能在创建会话时开启
+type VideoOptions struct {
+	Dir       string          // 保存目录，默认 当前目录/videos
+	Width     int             // 视频宽度，0 表示按视口缩放
+	Height    int             // 视频高度
+	Retention RetentionPolicy // 默认 RetainAlways
+}
+
+// sessionRecording 会话的追踪与视频录制状态
+type sessionRecording struct {
+	locker sync.Mutex
+	trace  *TraceOptions
+	video  *VideoOptions
+	failed error
+	saved  []string
+}
+
+func (r *sessionRecording) startTrace(context playwright.BrowserContext, sessionID string, opts TraceOptions) error {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+
+	if r.trace != nil {
+		return fmt.Errorf("会话 %s 已在录制追踪", sessionID)
+	}
+	if opts.Dir == "" {
+		opts.Dir = "traces"
+	}
+	err := context.Tracing().Start(playwright.TracingStartOptions{
+		Name:        playwright.String(sessionID),
+		Screenshots: playwright.Bool(opts.Screenshots),
+		Snapshots:   playwright.Bool(opts.Snapshots),
+		Sources:     playwright.Bool(opts.Sources),
+	})
+	if err != nil {
+		return fmt.Errorf("无法开始录制追踪: %w", err)
+	}
+	r.trace = &opts
+	return nil
+}
+
+func (r *sessionRecording) markFailed(err error) {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+	if err == nil {
+		err = fmt.Errorf("任务失败")
+	}
+	r.failed = err
+}
+
+func (r *sessionRecording) retain(policy RetentionPolicy) bool {
+	return policy != RetainOnFailure || r.failed != nil
+}
+
+// stopTrace 停止追踪，按保留策略保存或丢弃
+func (r *sessionRecording) stopTrace(context playwright.BrowserContext, sessionID string) error {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+
+	if r.trace == nil {
+		return nil
+	}
+	opts := r.trace
+	r.trace = nil
+	if !r.retain(opts.Retention) {
+		return context.Tracing().Stop()
+	}
+	if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+		return fmt.Errorf("无法创建追踪目录: %w", err)
+	}
+	path := filepath.Join(opts.Dir, fmt.Sprintf("trace_%s_%s.zip", sanitize_file_name(sessionID), time.Now().Format("20060102_150405")))
+	if err := context.Tracing().Stop(path); err != nil {
+		return fmt.Errorf("无法保存追踪文件: %w", err)
+	}
+	r.saved = append(r.saved, path)
+	log.Printf("追踪文件已保存: %s", path)
+	return nil
+}
+
+// saveVideos 在上下文关闭后按保留策略保存或删除各标签页的视频
+func (r *sessionRecording) saveVideos(videos map[string]playwright.Video) error {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+
+	if r.video == nil {
+		return nil
+	}
+	keep := r.retain(r.video.Retention)
+	if keep {
+		if err := os.MkdirAll(r.video.Dir, 0755); err != nil {
+			return fmt.Errorf("无法创建视频目录: %w", err)
+		}
+	}
+	var firstErr error
+	for tabID, video := range videos {
+		if keep {
+			path := filepath.Join(r.video.Dir, fmt.Sprintf("video_%s_%s.webm", sanitize_file_name(tabID), time.Now().Format("20060102_150405")))
+			if err := video.SaveAs(path); err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("无法保存视频: %w", err)
+				}
+			} else {
+				r.saved = append(r.saved, path)
+				log.Printf("视频已保存: %s", path)
+			}
+		}
+		if err := video.Delete(); err != nil {
+			log.Printf("删除临时视频失败: %v", err)
+		}
+	}
+	return firstErr
+}
+
+func (r *sessionRecording) savedFiles() []string {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+	return append([]string(nil), r.saved...)
+}
+
+func record_video_options(opts *VideoOptions) *playwright.RecordVideo {
+	if opts.Dir == "" {
+		opts.Dir = "videos"
+	}
+	// Playwright 先将视频写入临时目录，会话关闭时再按保留策略另存
+	record := &playwright.RecordVideo{Dir: filepath.Join(os.TempDir(), "browser-videos")}
+	if opts.Width > 0 && opts.Height > 0 {
+		record.Size = &playwright.Size{Width: opts.Width, Height: opts.Height}
+	}
+	return record
+}
@@ -0,0 +1,206 @@
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// CapturedResponse 捕获到的响应
+type CapturedResponse struct {
+	URL          string
+	Method       string
+	ResourceType string
+	Status       int
+	StatusText   string
+	Headers      map[string]string
+	Body         []byte
+	Time         time.Time // 捕获时间
+}
+
+// Text 以字符串返回响应体
+func (r *CapturedResponse) Text() string {
+	return string(r.Body)
+}
+
+// JSON 将响应体解码到 v
+func (r *CapturedResponse) JSON(v any) error {
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		return fmt.Errorf("无法解析响应 %s: %w", r.URL, err)
+	}
+	return nil
+}
+
+// DecodeJSON 将响应体解码为 T
+func DecodeJSON[T any](r *CapturedResponse) (T, error) {
+	var v T
+	err := r.JSON(&v)
+	return v, err
+}
+
+func capture_response(response playwright.Response) (*CapturedResponse, error) {
+	request := response.Request()
+	captured := &CapturedResponse{
+		URL:          response.URL(),
+		Method:       request.Method(),
+		ResourceType: request.ResourceType(),
+		Status:       response.Status(),
+		StatusText:   response.StatusText(),
+		Time:         time.Now(),
+	}
+	headers, err := response.AllHeaders()
+	if err != nil {
+		headers = response.Headers()
+	}
+	captured.Headers = headers
+	// 重定向响应没有响应体
+	if captured.Status >= 300 && captured.Status < 400 {
+		return captured, nil
+	}
+	body, err := response.Body()
+	if err != nil {
+		return captured, fmt.Errorf("无法读取响应体 %s: %w", captured.URL, err)
+	}
+	captured.Body = body
+	return captured, nil
+}
+
+// wait_for_response 执行 action 并等待第一个匹配的响应
+func wait_for_response(ctx context.Context, hub *eventHub[playwright.Response], matcher RouteMatcher, action func() error) (*CapturedResponse, error) {
+	match, err := compile_route_matcher(matcher)
+	if err != nil {
+		return nil, err
+	}
+
+	responseChan := make(chan playwright.Response, 1)
+	unsubscribe := hub.subscribe(func(response playwright.Response) {
+		if !match(new_route_request(response.Request())) {
+			return
+		}
+		select {
+		case responseChan <- response:
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	if action != nil {
+		if err := action(); err != nil {
+			return nil, fmt.Errorf("执行操作失败: %w", err)
+		}
+	}
+
+	select {
+	case response := <-responseChan:
+		return capture_response(response)
+	case <-ctx.Done():
+		return nil, fmt.Errorf("等待响应超时: %w", ctx.Err())
+	}
+}
+
+// ResponseRecorder 持续收集匹配的响应，直到 Stop 或上下文结束
+type ResponseRecorder struct {
+	locker      sync.Mutex
+	match       func(req *RouteRequest) bool
+	responses   []*CapturedResponse
+	channel     chan *CapturedResponse
+	pending     sync.WaitGroup
+	stopped     bool
+	stopOnce    sync.Once
+	done        chan struct{} // 停止完成(进行中的响应已收集、通道已关闭)后关闭
+	unsubscribe func()
+}
+
+func new_response_recorder(ctx context.Context, hub *eventHub[playwright.Response], matcher RouteMatcher, buffer int) (*ResponseRecorder, error) {
+	match, err := compile_route_matcher(matcher)
+	if err != nil {
+		return nil, err
+	}
+	recorder := &ResponseRecorder{
+		match:     match,
+		responses: make([]*CapturedResponse, 0),
+		done:      make(chan struct{}),
+	}
+	if buffer > 0 {
+		recorder.channel = make(chan *CapturedResponse, buffer)
+	}
+	recorder.unsubscribe = hub.subscribe(recorder.onResponse)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			recorder.Stop()
+		case <-recorder.done:
+		}
+	}()
+	return recorder, nil
+}
+
+func (r *ResponseRecorder) onResponse(response playwright.Response) {
+	if !r.match(new_route_request(response.Request())) {
+		return
+	}
+
+	r.locker.Lock()
+	if r.stopped {
+		r.locker.Unlock()
+		return
+	}
+	r.pending.Add(1)
+	r.locker.Unlock()
+
+	// 事件回调中不能同步读取响应体，否则会阻塞 Playwright 的消息分发
+	go func() {
+		defer r.pending.Done()
+		captured, err := capture_response(response)
+		if err != nil {
+			log.Printf("捕获响应失败: %v", err)
+		}
+
+		r.locker.Lock()
+		defer r.locker.Unlock()
+		r.responses = append(r.responses, captured)
+		if r.channel != nil {
+			select {
+			case r.channel <- captured:
+			default:
+				log.Printf("响应通道已满，丢弃: %s", captured.URL)
+			}
+		}
+	}()
+}
+
+// C 返回实时接收响应的通道，创建时 buffer 为 0 则返回 nil；Stop 后通道关闭
+func (r *ResponseRecorder) C() <-chan *CapturedResponse {
+	return r.channel
+}
+
+// Responses 返回目前已收集的响应
+func (r *ResponseRecorder) Responses() []*CapturedResponse {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+	return append([]*CapturedResponse(nil), r.responses...)
+}
+
+// Stop 停止收集并返回全部响应，可重复和并发调用，每次调用都等到进行中的响应收集完成后返回
+func (r *ResponseRecorder) Stop() []*CapturedResponse {
+	r.stopOnce.Do(func() {
+		r.locker.Lock()
+		r.stopped = true
+		r.locker.Unlock()
+		r.unsubscribe()
+
+		r.pending.Wait()
+		if r.channel != nil {
+			close(r.channel)
+		}
+		close(r.done)
+	})
+	<-r.done
+	return r.Responses()
+}
@@ -0,0 +1,69 @@
+package handle
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+func TestResponseRecorderConcurrentStop(t *testing.T) {
+	hub := newEventHub[playwright.Response]()
+	recorder, err := new_response_recorder(context.Background(), hub, RouteMatcher{}, 1)
+	if err != nil {
+		t.Fatalf("创建响应收集器失败: %v", err)
+	}
+
+	// 模拟正在捕获的响应
+	recorder.locker.Lock()
+	recorder.pending.Add(1)
+	recorder.locker.Unlock()
+
+	var (
+		wg       sync.WaitGroup
+		finished = make(chan struct{}, 3)
+	)
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recorder.Stop()
+			finished <- struct{}{}
+		}()
+	}
+	select {
+	case <-finished:
+		t.Fatalf("进行中的响应未完成时 Stop 不应返回")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	recorder.locker.Lock()
+	recorder.responses = append(recorder.responses, &CapturedResponse{URL: "https://example.com/api"})
+	recorder.locker.Unlock()
+	recorder.pending.Done()
+	wg.Wait()
+
+	if got := recorder.Stop(); len(got) != 1 {
+		t.Fatalf("Stop 返回的响应数异常: %d", len(got))
+	}
+	if _, ok := <-recorder.C(); ok {
+		t.Fatalf("Stop 后通道应已关闭")
+	}
+}
+
+func TestResponseRecorderStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	recorder, err := new_response_recorder(ctx, newEventHub[playwright.Response](), RouteMatcher{}, 1)
+	if err != nil {
+		t.Fatalf("创建响应收集器失败: %v", err)
+	}
+	cancel()
+	select {
+	case <-recorder.done:
+	case <-time.After(time.Second):
+		t.Fatalf("上下文结束后收集器未停止")
+	}
+	recorder.Stop()
+}
@@ -0,0 +1,173 @@
+package handle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// RetryPolicy 重试策略，零值表示不重试
+type RetryPolicy struct {
+	MaxAttempts   int                                               // 总尝试次数，小于等于 1 表示不重试
+	BaseDelay     time.Duration                                     // 首次重试前的等待，默认 500ms
+	MaxDelay      time.Duration                                     // 等待上限，默认 30s
+	Multiplier    float64                                           // 每次重试等待的增长倍数，默认 2
+	Jitter        float64                                           // 随机抖动比例 0-1，0 表示不抖动
+	Retryable     func(err error) bool                              // 判断错误是否可重试，默认 IsTransientError
+	ReloadOnRetry bool                                              // 因导航类错误失败时，重试前先重新加载页面
+	OnRetry       func(attempt int, err error, delay time.Duration) // 每次重试前回调，可为空
+}
+
+// DefaultRetryPolicy 最多尝试 3 次，等待 500ms、1s，抖动 20%
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Multiplier:  2,
+		Jitter:      0.2,
+	}
+}
+
+// 浏览器在连接不稳定时常见的可恢复错误
+var transientNetErrors = []string{
+	"net::ERR_CONNECTION_RESET",
+	"net::ERR_CONNECTION_CLOSED",
+	"net::ERR_CONNECTION_ABORTED",
+	"net::ERR_CONNECTION_TIMED_OUT",
+	"net::ERR_TIMED_OUT",
+	"net::ERR_EMPTY_RESPONSE",
+	"net::ERR_NETWORK_CHANGED",
+	"net::ERR_INTERNET_DISCONNECTED",
+	"net::ERR_PROXY_CONNECTION_FAILED",
+	"net::ERR_HTTP2_PROTOCOL_ERROR",
+	"net::ERR_SSL_PROTOCOL_ERROR",
+}
+
+// IsTransientError 判断错误是否可能在重试后恢复：超时、连接中断、执行上下文因导航被销毁
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) || errors.Is(err, playwright.ErrTimeout) {
+		return true
+	}
+	message := err.Error()
+	for _, code := range transientNetErrors {
+		if strings.Contains(message, code) {
+			return true
+		}
+	}
+	return strings.Contains(message, "Execution context was destroyed")
+}
+
+// IsNavigationError 判断错误是否由页面加载失败或页面跳转引起
+func IsNavigationError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	return strings.Contains(message, "net::ERR_") || strings.Contains(message, "Execution context was destroyed")
+}
+
+type retryKey struct{}
+
+// WithRetry 为单次调用指定重试策略，优先于浏览器级策略，适用于接受 ctx 的 Navigate、Element.Click 等方法
+func WithRetry(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryKey{}, policy)
+}
+
+// Retry 按策略重试 op，op 收到的 ctx 已关闭内部重试，避免嵌套
+func Retry(ctx context.Context, policy RetryPolicy, op func(ctx context.Context) error) error {
+	return retry(ctx, policy, nil, op)
+}
+
+// resolve_retry_policy 依次使用 ctx 中的策略、浏览器级策略，都没有时不重试
+func resolve_retry_policy(ctx context.Context, fallback *RetryPolicy) RetryPolicy {
+	if policy, ok := ctx.Value(retryKey{}).(RetryPolicy); ok {
+		return policy
+	}
+	if fallback != nil {
+		return *fallback
+	}
+	return RetryPolicy{}
+}
+
+func retry(ctx context.Context, policy RetryPolicy, reload func(ctx context.Context) error, op func(ctx context.Context) error) error {
+	attempts := max(policy.MaxAttempts, 1)
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsTransientError
+	}
+	inner := WithRetry(ctx, RetryPolicy{})
+
+	for attempt := 1; ; attempt++ {
+		err := op(inner)
+		if err == nil {
+			return nil
+		}
+		if attempt >= attempts || ctx.Err() != nil || !retryable(err) {
+			if attempt > 1 {
+				return fmt.Errorf("尝试 %d 次后仍失败: %w", attempt, err)
+			}
+			return err
+		}
+
+		delay := policy.delay(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, delay)
+		}
+		log.Printf("第 %d 次尝试失败，%s 后重试: %v", attempt, delay.Round(time.Millisecond), err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("尝试 %d 次后仍失败: %w", attempt, err)
+		case <-timer.C:
+		}
+
+		if policy.ReloadOnRetry && reload != nil && IsNavigationError(err) {
+			if reloadErr := reload(inner); reloadErr != nil {
+				log.Printf("重新加载页面失败: %v", reloadErr)
+			}
+		}
+	}
+}
+
+// delay 第 attempt 次失败后的等待时间
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	base, maxDelay, multiplier := p.BaseDelay, p.MaxDelay, p.Multiplier
+	if base <= 0 {
+		base = 500 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	delay := float64(base) * math.Pow(multiplier, float64(attempt-1))
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 {
+		delay *= 1 + jitter*(rand.Float64()*2-1)
+	}
+	return time.Duration(min(delay, float64(maxDelay)))
+}
+
+// reload_page 重试前重新加载页面
+func reload_page(page playwright.Page) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := page.Reload(playwright.PageReloadOptions{
+			WaitUntil: playwright.WaitUntilStateDomcontentloaded,
+			Timeout:   ctx_timeout(ctx),
+		})
+		return err
+	}
+}
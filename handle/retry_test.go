@@ -0,0 +1,135 @@
+package handle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{"默认首次等待", RetryPolicy{}, 1, 500 * time.Millisecond},
+		{"默认倍数", RetryPolicy{}, 3, 2 * time.Second},
+		{"自定义倍数", RetryPolicy{BaseDelay: 100 * time.Millisecond, Multiplier: 3}, 3, 900 * time.Millisecond},
+		{"倍数小于 1 时使用默认值", RetryPolicy{BaseDelay: time.Second, Multiplier: 0.5}, 2, 2 * time.Second},
+		{"不超过上限", RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second}, 5, 3 * time.Second},
+		{"默认上限", RetryPolicy{BaseDelay: time.Second}, 20, 30 * time.Second},
+	}
+	for _, c := range cases {
+		if got := c.policy.delay(c.attempt); got != c.want {
+			t.Errorf("%s: 第 %d 次等待 %v，期望 %v", c.name, c.attempt, got, c.want)
+		}
+	}
+
+	policy := RetryPolicy{BaseDelay: time.Second, Jitter: 0.2}
+	for range 100 {
+		if got := policy.delay(1); got < 800*time.Millisecond || got > 1200*time.Millisecond {
+			t.Fatalf("抖动超出范围: %v", got)
+		}
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	cases := []struct {
+		err       error
+		transient bool
+	}{
+		{nil, false},
+		{context.Canceled, false},
+		{fmt.Errorf("等待: %w", context.DeadlineExceeded), false},
+		{&TimeoutError{Condition: "元素可见", Err: errors.New("timeout")}, true},
+		{fmt.Errorf("点击失败: %w", playwright.ErrTimeout), true},
+		{errors.New("page.goto: net::ERR_CONNECTION_RESET at https://example.com"), true},
+		{errors.New("Execution context was destroyed, most likely because of a navigation"), true},
+		{errors.New("net::ERR_NAME_NOT_RESOLVED"), false},
+		{ErrElementNotFound, false},
+	}
+	for _, c := range cases {
+		if got := IsTransientError(c.err); got != c.transient {
+			t.Errorf("IsTransientError(%v) = %v，期望 %v", c.err, got, c.transient)
+		}
+	}
+}
+
+func TestIsNavigationError(t *testing.T) {
+	cases := []struct {
+		err        error
+		navigation bool
+	}{
+		{nil, false},
+		{errors.New("net::ERR_NAME_NOT_RESOLVED"), true},
+		{errors.New("Execution context was destroyed"), true},
+		{&TimeoutError{Condition: "元素可见", Err: errors.New("timeout")}, false},
+	}
+	for _, c := range cases {
+		if got := IsNavigationError(c.err); got != c.navigation {
+			t.Errorf("IsNavigationError(%v) = %v，期望 %v", c.err, got, c.navigation)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	transient := errors.New("net::ERR_CONNECTION_RESET")
+	permanent := errors.New("选择器无效")
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	cases := []struct {
+		name     string
+		policy   RetryPolicy
+		failures []error // 依次返回的错误，用完后成功
+		attempts int
+		wantErr  error
+	}{
+		{"首次成功", policy, nil, 1, nil},
+		{"重试后成功", policy, []error{transient, transient}, 3, nil},
+		{"超过次数", policy, []error{transient, transient, transient}, 3, transient},
+		{"不可重试的错误", policy, []error{permanent}, 1, permanent},
+		{"零值策略不重试", RetryPolicy{}, []error{transient}, 1, transient},
+		{"自定义判断", RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, Retryable: func(err error) bool { return err == permanent }},
+			[]error{permanent}, 2, nil},
+	}
+	for _, c := range cases {
+		attempts := 0
+		err := retry(context.Background(), c.policy, nil, func(ctx context.Context) error {
+			attempts++
+			if _, ok := ctx.Value(retryKey{}).(RetryPolicy); !ok {
+				t.Errorf("%s: op 收到的 ctx 应关闭内部重试", c.name)
+			}
+			if attempts <= len(c.failures) {
+				return c.failures[attempts-1]
+			}
+			return nil
+		})
+		if attempts != c.attempts {
+			t.Errorf("%s: 尝试 %d 次，期望 %d 次", c.name, attempts, c.attempts)
+		}
+		if !errors.Is(err, c.wantErr) || (c.wantErr == nil) != (err == nil) {
+			t.Errorf("%s: 返回 %v，期望 %v", c.name, err, c.wantErr)
+		}
+	}
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	var reloads int
+	err := retry(ctx, RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, ReloadOnRetry: true},
+		func(ctx context.Context) error { reloads++; return nil },
+		func(ctx context.Context) error {
+			attempts++
+			cancel()
+			return errors.New("net::ERR_CONNECTION_RESET")
+		})
+	if attempts != 1 || reloads != 0 || err == nil {
+		t.Fatalf("ctx 结束后应停止重试: attempts=%d reloads=%d err=%v", attempts, reloads, err)
+	}
+}
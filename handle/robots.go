@@ -0,0 +1,145 @@
+package handle
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RobotsRules robots.txt 中适用于某个 User-Agent 的规则，nil 表示允许所有路径
+type RobotsRules struct {
+	CrawlDelay time.Duration // Crawl-delay 指定的抓取间隔，未指定时为 0
+	Sitemaps   []string      // Sitemap 声明的地址
+	rules      []robotsRule
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+// robotsGroup 由连续的 User-agent 行开始的一组规则
+type robotsGroup struct {
+	agents     []string
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// ParseRobots 解析 robots.txt，选取与 userAgent 匹配最长的分组，没有匹配时使用 * 分组；
+// 同名的多个分组合并
+func ParseRobots(data []byte, userAgent string) *RobotsRules {
+	var groups []*robotsGroup
+	var current *robotsGroup
+	var sitemaps []string
+	inAgents := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgents {
+				current = &robotsGroup{}
+				groups = append(groups, current)
+				inAgents = true
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+		case "allow", "disallow":
+			inAgents = false
+			// 空的 Disallow 表示不限制
+			if current == nil || value == "" {
+				continue
+			}
+			current.rules = append(current.rules, new_robots_rule(key == "allow", value))
+		case "crawl-delay":
+			inAgents = false
+			if current == nil {
+				continue
+			}
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				current.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		case "sitemap":
+			sitemaps = append(sitemaps, value)
+		default:
+			inAgents = false
+		}
+	}
+
+	rules := &RobotsRules{Sitemaps: sitemaps}
+	for _, group := range select_robots_groups(groups, strings.ToLower(userAgent)) {
+		rules.rules = append(rules.rules, group.rules...)
+		rules.CrawlDelay = max(rules.CrawlDelay, group.crawlDelay)
+	}
+	return rules
+}
+
+// select_robots_groups 返回名称包含于 userAgent 且最长的分组，没有时返回 * 分组
+func select_robots_groups(groups []*robotsGroup, userAgent string) []*robotsGroup {
+	best := ""
+	for _, group := range groups {
+		for _, agent := range group.agents {
+			if agent != "*" && agent != "" && strings.Contains(userAgent, agent) && len(agent) > len(best) {
+				best = agent
+			}
+		}
+	}
+	if best == "" {
+		best = "*"
+	}
+	var selected []*robotsGroup
+	for _, group := range groups {
+		for _, agent := range group.agents {
+			if agent == best {
+				selected = append(selected, group)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// new_robots_rule 将路径规则转换为正则: * 匹配任意字符，结尾的 $ 匹配路径结尾
+func new_robots_rule(allow bool, pattern string) robotsRule {
+	anchored := strings.HasSuffix(pattern, "$")
+	expr := regexp.QuoteMeta(strings.TrimSuffix(pattern, "$"))
+	expr = "^" + strings.ReplaceAll(expr, `\*`, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return robotsRule{allow: allow, pattern: pattern, re: regexp.MustCompile(expr)}
+}
+
+// Allowed 判断路径(含查询参数)是否允许抓取，最长匹配的规则生效，长度相同时 Allow 优先
+func (r *RobotsRules) Allowed(path string) bool {
+	if r == nil || path == "/robots.txt" {
+		return true
+	}
+	if path == "" {
+		path = "/"
+	}
+	allowed, longest := true, -1
+	for _, rule := range r.rules {
+		if !rule.re.MatchString(path) {
+			continue
+		}
+		if n := len(rule.pattern); n > longest || (n == longest && rule.allow) {
+			allowed, longest = rule.allow, n
+		}
+	}
+	return allowed
+}
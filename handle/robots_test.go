@@ -0,0 +1,91 @@
+package handle
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+const testRobots = `# 示例
+User-agent: *
+Disallow: /private/
+Allow: /private/public.html
+Disallow: /*.pdf$
+Crawl-delay: 1.5
+
+User-agent: GoodBot
+User-agent: OtherBot
+Disallow: /admin
+Disallow:
+
+User-agent: goodbot
+Disallow: /tmp/
+Crawl-delay: 2
+
+Sitemap: https://example.com/sitemap.xml
+`
+
+func TestParseRobots(t *testing.T) {
+	cases := []struct {
+		agent   string
+		path    string
+		allowed bool
+	}{
+		{"Mozilla/5.0", "/", true},
+		{"Mozilla/5.0", "/private/a.html", false},
+		{"Mozilla/5.0", "/private/public.html", true},
+		{"Mozilla/5.0", "/docs/a.pdf", false},
+		{"Mozilla/5.0", "/docs/a.pdf?download=1", true},
+		{"Mozilla/5.0", "/robots.txt", true},
+		{"Mozilla/5.0 GoodBot/1.0", "/private/a.html", true},
+		{"Mozilla/5.0 GoodBot/1.0", "/admin/users", false},
+		{"Mozilla/5.0 GoodBot/1.0", "/tmp/x", false},
+		{"otherbot", "/tmp/x", true},
+		{"otherbot", "/administrator", false},
+	}
+	for _, c := range cases {
+		rules := ParseRobots([]byte(testRobots), c.agent)
+		if got := rules.Allowed(c.path); got != c.allowed {
+			t.Errorf("%s 访问 %s = %v，期望 %v", c.agent, c.path, got, c.allowed)
+		}
+	}
+
+	rules := ParseRobots([]byte(testRobots), "Mozilla/5.0")
+	if rules.CrawlDelay != 1500*time.Millisecond {
+		t.Errorf("Crawl-delay 解析错误: %v", rules.CrawlDelay)
+	}
+	if !slices.Equal(rules.Sitemaps, []string{"https://example.com/sitemap.xml"}) {
+		t.Errorf("Sitemap 解析错误: %v", rules.Sitemaps)
+	}
+	if delay := ParseRobots([]byte(testRobots), "GoodBot").CrawlDelay; delay != 2*time.Second {
+		t.Errorf("同名分组的 Crawl-delay 应合并: %v", delay)
+	}
+}
+
+func TestRobotsAllowedLongestMatch(t *testing.T) {
+	cases := []struct {
+		robots  string
+		path    string
+		allowed bool
+	}{
+		{"User-agent: *\nDisallow: /\nAllow: /public", "/public/a", true},
+		{"User-agent: *\nDisallow: /\nAllow: /public", "/other", false},
+		// 长度相同时 Allow 优先
+		{"User-agent: *\nDisallow: /page\nAllow: /page", "/page", true},
+		{"User-agent: *\nDisallow: /*.php$", "/index.php", false},
+		{"User-agent: *\nDisallow: /*.php$", "/index.php5", true},
+		{"User-agent: *\nDisallow: /search?q=", "/search?q=go", false},
+		{"User-agent: other\nDisallow: /", "/", true},
+		{"", "/anything", true},
+	}
+	for _, c := range cases {
+		if got := ParseRobots([]byte(c.robots), "bot").Allowed(c.path); got != c.allowed {
+			t.Errorf("%q 访问 %s = %v，期望 %v", c.robots, c.path, got, c.allowed)
+		}
+	}
+
+	var rules *RobotsRules
+	if !rules.Allowed("/x") {
+		t.Errorf("nil 规则应允许所有路径")
+	}
+}
@@ -0,0 +1,363 @@
+package handle
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// RouteMatcher 请求匹配条件，所有非空条件需同时满足
+type RouteMatcher struct {
+	URLGlob       string         // URL 通配符，* 不跨越 /，** 匹配任意字符
+	URLRegex      *regexp.Regexp // URL 正则
+	Methods       []string       // 请求方法，如 GET、POST
+	ResourceTypes []string       // 资源类型，如 document、script、image、xhr、fetch
+	Predicate     func(req *RouteRequest) bool
+}
+
+type RouteActionKind int
+
+const (
+	RouteActionAbort    RouteActionKind = iota // 中止请求
+	RouteActionContinue                        // 放行请求，可修改请求头和请求体
+	RouteActionFulfill                         // 使用本地文件或 Go 函数直接响应
+)
+
+// RouteRequest 被拦截的请求
+type RouteRequest struct {
+	URL          string
+	Method       string
+	Headers      map[string]string
+	ResourceType string
+	PostData     []byte
+}
+
+// RouteResponse 用于直接响应被拦截请求的内容
+type RouteResponse struct {
+	Status      int
+	Headers     map[string]string
+	ContentType string
+	Body        []byte
+}
+
+// RouteAction 命中规则后对请求执行的动作，使用 AbortRoute、ContinueRoute、FulfillFile、FulfillFunc 构造
+type RouteAction struct {
+	Kind          RouteActionKind
+	ErrorCode     string            // Abort: 错误码，如 failed、blockedbyclient，为空时默认 failed
+	Headers       map[string]string // Continue: 追加或覆盖的请求头
+	RemoveHeaders []string          // Continue: 删除的请求头
+	PostData      []byte            // Continue: 替换的请求体
+	FilePath      string            // Fulfill: 响应文件
+	Status        int               // Fulfill: 响应状态码
+	ContentType   string            // Fulfill: 响应类型
+	Handler       func(req *RouteRequest) (*RouteResponse, error)
+}
+
+func AbortRoute(errorCode string) RouteAction {
+	return RouteAction{Kind: RouteActionAbort, ErrorCode: errorCode}
+}
+
+func ContinueRoute(headers map[string]string, removeHeaders []string, postData []byte) RouteAction {
+	return RouteAction{Kind: RouteActionContinue, Headers: headers, RemoveHeaders: removeHeaders, PostData: postData}
+}
+
+func FulfillFile(path string, status int, contentType string) RouteAction {
+	return RouteAction{Kind: RouteActionFulfill, FilePath: path, Status: status, ContentType: contentType}
+}
+
+// FulfillFunc 由 Go 函数生成响应，返回 nil 时交给后续规则处理
+func FulfillFunc(handler func(req *RouteRequest) (*RouteResponse, error)) RouteAction {
+	return RouteAction{Kind: RouteActionFulfill, Handler: handler}
+}
+
+type routeRule struct {
+	name   string
+	match  func(req *RouteRequest) bool
+	action RouteAction
+	hits   *atomic.Int64 // 已处理的请求数，同名规则删除后重新添加时继续累计
+}
+
+// routeTable 按注册顺序保存命名规则，只向 Playwright 注册一个总的路由处理函数
+type routeTable struct {
+	locker    sync.Mutex
+	rules     []*routeRule
+	counters  map[string]*atomic.Int64 // 按规则名称保存的计数，规则删除后保留
+	installed bool
+	install   func(handler func(playwright.Route)) error
+	uninstall func(handler func(playwright.Route)) error
+}
+
+// newRouteTable install、uninstall 收到同一个处理函数，注销时只移除该函数，保留 ReplayHAR 等其他路由
+func newRouteTable(install func(handler func(playwright.Route)) error, uninstall func(handler func(playwright.Route)) error) *routeTable {
+	return &routeTable{
+		rules:     make([]*routeRule, 0),
+		counters:  make(map[string]*atomic.Int64),
+		install:   install,
+		uninstall: uninstall,
+	}
+}
+
+// add 将规则追加到末尾，先注册的规则优先
+func (r *routeTable) add(name string, matcher RouteMatcher, action RouteAction) error {
+	if strings.HasPrefix(name, blockRulePrefix) {
+		return fmt.Errorf("路由规则名称不能以 %s 开头，该前缀保留给 BlockResources: %s", blockRulePrefix, name)
+	}
+	return r.insert(name, matcher, action, false)
+}
+
+// prepend 将规则插入到本路由表的最前，优先于表中已注册的所有规则；
+// 标签页的路由表先于会话的路由表执行，会话表中的规则仍可能被标签页规则放行
+func (r *routeTable) prepend(name string, matcher RouteMatcher, action RouteAction) error {
+	return r.insert(name, matcher, action, true)
+}
+
+func (r *routeTable) insert(name string, matcher RouteMatcher, action RouteAction, front bool) error {
+	if name == "" {
+		return fmt.Errorf("路由规则名称不能为空")
+	}
+	if action.Kind == RouteActionFulfill && action.Handler == nil && action.FilePath == "" {
+		return fmt.Errorf("路由规则 %s 未指定响应文件或处理函数", name)
+	}
+	match, err := compile_route_matcher(matcher)
+	if err != nil {
+		return err
+	}
+	r.locker.Lock()
+	defer r.locker.Unlock()
+
+	if slices.ContainsFunc(r.rules, func(item *routeRule) bool { return item.name == name }) {
+		return fmt.Errorf("路由规则已存在: %s", name)
+	}
+	if !r.installed {
+		if err := r.install(r.handle); err != nil {
+			return fmt.Errorf("注册路由失败: %w", err)
+		}
+		r.installed = true
+	}
+	rule := &routeRule{name: name, match: match, action: action, hits: r.counterLocked(name)}
+	if front {
+		r.rules = slices.Insert(r.rules, 0, rule)
+	} else {
+		r.rules = append(r.rules, rule)
+	}
+	return nil
+}
+
+func (r *routeTable) remove(name string) error {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+
+	index := slices.IndexFunc(r.rules, func(item *routeRule) bool { return item.name == name })
+	if index < 0 {
+		return fmt.Errorf("未找到路由规则: %s", name)
+	}
+	r.rules = slices.Delete(r.rules, index, index+1)
+	if len(r.rules) == 0 && r.installed {
+		r.installed = false
+		if err := r.uninstall(r.handle); err != nil {
+			return fmt.Errorf("注销路由失败: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *routeTable) names() []string {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+
+	names := make([]string, 0, len(r.rules))
+	for _, rule := range r.rules {
+		names = append(names, rule.name)
+	}
+	return names
+}
+
+// counter 返回该名称的累计计数，不存在时创建；供不经过路由的屏蔽方式计数
+func (r *routeTable) counter(name string) *atomic.Int64 {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+	return r.counterLocked(name)
+}
+
+func (r *routeTable) counterLocked(name string) *atomic.Int64 {
+	counter, ok := r.counters[name]
+	if !ok {
+		counter = new(atomic.Int64)
+		r.counters[name] = counter
+	}
+	return counter
+}
+
+// total 返回该名称的规则累计处理的请求数，包括已删除的规则；从未添加过时返回 false
+func (r *routeTable) total(name string) (int64, bool) {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+
+	counter, ok := r.counters[name]
+	if !ok {
+		return 0, false
+	}
+	return counter.Load(), true
+}
+
+// hits 返回规则已处理的请求数，规则不存在时返回 -1
+func (r *routeTable) hits(name string) int64 {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+
+	for _, rule := range r.rules {
+		if rule.name == name {
+			return rule.hits.Load()
+		}
+	}
+	return -1
+}
+
+func (r *routeTable) handle(route playwright.Route) {
+	r.locker.Lock()
+	rules := slices.Clone(r.rules)
+	r.locker.Unlock()
+
+	req := new_route_request(route.Request())
+	for _, rule := range rules {
+		if !rule.match(req) {
+			continue
+		}
+		handled, err := rule.apply(route, req)
+		if err != nil {
+			log.Printf("执行路由规则 %s 失败: %v", rule.name, err)
+		}
+		if handled {
+			rule.hits.Add(1)
+			return
+		}
+	}
+	if err := route.Fallback(); err != nil {
+		log.Printf("放行请求失败: %v", err)
+	}
+}
+
+// compile_route_matcher 将匹配条件编译为判断函数
+func compile_route_matcher(matcher RouteMatcher) (func(req *RouteRequest) bool, error) {
+	var glob *regexp.Regexp
+	if matcher.URLGlob != "" {
+		re, err := glob_to_regexp(matcher.URLGlob)
+		if err != nil {
+			return nil, fmt.Errorf("无效的 URL 通配符 %s: %w", matcher.URLGlob, err)
+		}
+		glob = re
+	}
+	return func(req *RouteRequest) bool {
+		if glob != nil && !glob.MatchString(req.URL) {
+			return false
+		}
+		if matcher.URLRegex != nil && !matcher.URLRegex.MatchString(req.URL) {
+			return false
+		}
+		if len(matcher.Methods) > 0 && !slices.ContainsFunc(matcher.Methods, func(method string) bool {
+			return strings.EqualFold(method, req.Method)
+		}) {
+			return false
+		}
+		if len(matcher.ResourceTypes) > 0 && !slices.Contains(matcher.ResourceTypes, req.ResourceType) {
+			return false
+		}
+		if matcher.Predicate != nil && !matcher.Predicate(req) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+// apply 执行规则动作，返回请求是否已被处理
+func (rule *routeRule) apply(route playwright.Route, req *RouteRequest) (bool, error) {
+	action := rule.action
+	switch action.Kind {
+	case RouteActionAbort:
+		errorCode := action.ErrorCode
+		if errorCode == "" {
+			errorCode = "failed"
+		}
+		return true, route.Abort(errorCode)
+	case RouteActionContinue:
+		headers := make(map[string]string, len(req.Headers)+len(action.Headers))
+		for k, v := range req.Headers {
+			headers[k] = v
+		}
+		for _, k := range action.RemoveHeaders {
+			delete(headers, strings.ToLower(k))
+		}
+		for k, v := range action.Headers {
+			headers[strings.ToLower(k)] = v
+		}
+		options := playwright.RouteContinueOptions{Headers: headers}
+		if action.PostData != nil {
+			options.PostData = action.PostData
+		}
+		return true, route.Continue(options)
+	case RouteActionFulfill:
+		if action.Handler == nil {
+			options := playwright.RouteFulfillOptions{Path: playwright.String(action.FilePath)}
+			if action.Status > 0 {
+				options.Status = playwright.Int(action.Status)
+			}
+			if action.ContentType != "" {
+				options.ContentType = playwright.String(action.ContentType)
+			}
+			return true, route.Fulfill(options)
+		}
+		resp, err := action.Handler(req)
+		if err != nil {
+			return true, errors.Join(err, route.Abort("failed"))
+		}
+		if resp == nil {
+			return false, nil
+		}
+		options := playwright.RouteFulfillOptions{Body: resp.Body, Headers: resp.Headers}
+		if resp.Status > 0 {
+			options.Status = playwright.Int(resp.Status)
+		}
+		if resp.ContentType != "" {
+			options.ContentType = playwright.String(resp.ContentType)
+		}
+		return true, route.Fulfill(options)
+	}
+	return false, fmt.Errorf("未知的路由动作: %d", action.Kind)
+}
+
+// routeSeq 为临时路由生成唯一标记
+var routeSeq atomic.Int64
+
+// unique_route_pattern 将通配符转换为正则并附加一个不影响匹配的唯一标记，glob 为空时匹配所有地址；
+// Playwright 按正则字符串区分路由，Unroute 时只会移除用该正则注册的路由
+func unique_route_pattern(glob string) (*regexp.Regexp, error) {
+	expr := ""
+	if glob != "" {
+		re, err := glob_to_regexp(glob)
+		if err != nil {
+			return nil, fmt.Errorf("无效的 URL 通配符 %s: %w", glob, err)
+		}
+		expr = re.String()
+	}
+	return regexp.Compile(fmt.Sprintf("%s(?:route-%d){0}", expr, routeSeq.Add(1)))
+}
+
+func new_route_request(request playwright.Request) *RouteRequest {
+	req := &RouteRequest{
+		URL:          request.URL(),
+		Method:       request.Method(),
+		Headers:      request.Headers(),
+		ResourceType: request.ResourceType(),
+	}
+	if body, err := request.PostDataBuffer(); err == nil {
+		req.PostData = body
+	}
+	return req
+}
@@ -0,0 +1,177 @@
+package handle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ScreenshotFormat 截图格式
+type ScreenshotFormat string
+
+const (
+	ScreenshotPNG  ScreenshotFormat = "png"
+	ScreenshotJPEG ScreenshotFormat = "jpeg"
+)
+
+// ClipRect 页面上的矩形区域，单位为 CSS 像素，用作截图区域和元素边界
+type ClipRect struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+// ScreenshotOptions 截图选项，Selector、Clip、FullPage 互斥，都为空时截取当前视口
+type ScreenshotOptions struct {
+	FullPage       bool             // 截取整个页面
+	Selector       string           // 只截取匹配的第一个元素
+	Clip           *ClipRect        // 只截取指定区域
+	Format         ScreenshotFormat // 默认 PNG，Path 以 .jpg/.jpeg 结尾时默认 JPEG
+	Quality        int              // JPEG 质量 0-100，0 表示使用默认值
+	Mask           []string         // 需要遮盖的敏感元素选择器
+	MaskColor      string           // 遮盖颜色，默认 #FF00FF
+	OmitBackground bool             // 透明背景，仅 PNG 有效
+	Path           string           // 保存路径，可为空
+	Writer         io.Writer        // 输出目标，可为空
+}
+
+func take_screenshot(ctx context.Context, page playwright.Page, opts ScreenshotOptions) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	modes := 0
+	for _, set := range []bool{opts.FullPage, opts.Selector != "", opts.Clip != nil} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return nil, fmt.Errorf("FullPage、Selector、Clip 只能指定一个")
+	}
+	if opts.Selector != "" {
+		return take_element_screenshot(ctx, page.Locator(opts.Selector).First(), opts)
+	}
+
+	screenshotType, quality, err := screenshot_type(opts)
+	if err != nil {
+		return nil, err
+	}
+	masks := make([]playwright.Locator, 0, len(opts.Mask))
+	for _, selector := range opts.Mask {
+		masks = append(masks, page.Locator(selector))
+	}
+	var maskColor *string
+	if opts.MaskColor != "" {
+		maskColor = playwright.String(opts.MaskColor)
+	}
+
+	options := playwright.PageScreenshotOptions{
+		Type:           screenshotType,
+		Quality:        quality,
+		Mask:           masks,
+		MaskColor:      maskColor,
+		FullPage:       playwright.Bool(opts.FullPage),
+		OmitBackground: playwright.Bool(opts.OmitBackground),
+		Timeout:        ctx_timeout(ctx),
+	}
+	if opts.Clip != nil {
+		options.Clip = &playwright.Rect{X: opts.Clip.X, Y: opts.Clip.Y, Width: opts.Clip.Width, Height: opts.Clip.Height}
+	}
+	data, err := page.Screenshot(options)
+	if err != nil {
+		return nil, fmt.Errorf("截图失败: %w", err)
+	}
+	return data, write_screenshot(data, opts)
+}
+
+// take_element_screenshot 截取元素，忽略 FullPage、Selector 和 Clip
+func take_element_screenshot(ctx context.Context, locator playwright.Locator, opts ScreenshotOptions) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	screenshotType, quality, err := screenshot_type(opts)
+	if err != nil {
+		return nil, err
+	}
+	masks := make([]playwright.Locator, 0, len(opts.Mask))
+	if len(opts.Mask) > 0 {
+		page, err := locator.Page()
+		if err != nil {
+			return nil, fmt.Errorf("截图失败: %w", err)
+		}
+		for _, selector := range opts.Mask {
+			masks = append(masks, page.Locator(selector))
+		}
+	}
+	var maskColor *string
+	if opts.MaskColor != "" {
+		maskColor = playwright.String(opts.MaskColor)
+	}
+
+	data, err := locator.Screenshot(playwright.LocatorScreenshotOptions{
+		Type:           screenshotType,
+		Quality:        quality,
+		Mask:           masks,
+		MaskColor:      maskColor,
+		OmitBackground: playwright.Bool(opts.OmitBackground),
+		Timeout:        ctx_timeout(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("截图失败: %w", err)
+	}
+	return data, write_screenshot(data, opts)
+}
+
+// screenshot_type 根据 Format 和 Path 确定截图格式和质量
+func screenshot_type(opts ScreenshotOptions) (*playwright.ScreenshotType, *int, error) {
+	format := opts.Format
+	if format == "" {
+		ext := strings.ToLower(filepath.Ext(opts.Path))
+		if ext == ".jpg" || ext == ".jpeg" {
+			format = ScreenshotJPEG
+		} else {
+			format = ScreenshotPNG
+		}
+	}
+	var screenshotType *playwright.ScreenshotType
+	switch format {
+	case ScreenshotPNG:
+		screenshotType = playwright.ScreenshotTypePng
+	case ScreenshotJPEG:
+		screenshotType = playwright.ScreenshotTypeJpeg
+	default:
+		return nil, nil, fmt.Errorf("不支持的截图格式: %s", format)
+	}
+	var quality *int
+	if opts.Quality > 0 {
+		if format != ScreenshotJPEG {
+			return nil, nil, fmt.Errorf("只有 JPEG 格式支持设置质量")
+		}
+		quality = playwright.Int(min(opts.Quality, 100))
+	}
+	return screenshotType, quality, nil
+}
+
+// write_screenshot 将截图保存到 Path 并输出到 Writer
+func write_screenshot(data []byte, opts ScreenshotOptions) error {
+	if opts.Path != "" {
+		if err := os.MkdirAll(filepath.Dir(opts.Path), 0755); err != nil {
+			return fmt.Errorf("无法创建截图目录: %w", err)
+		}
+		if err := os.WriteFile(opts.Path, data, 0644); err != nil {
+			return fmt.Errorf("无法保存截图: %w", err)
+		}
+	}
+	if opts.Writer != nil {
+		if _, err := opts.Writer.Write(data); err != nil {
+			return fmt.Errorf("无法输出截图: %w", err)
+		}
+	}
+	return nil
+}
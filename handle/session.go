@@ -0,0 +1,12 @@
+package handle
+
+// SessionOptions 新建会话的选项
+type SessionOptions struct {
+	Trace *TraceOptions // 创建时即开始录制追踪
+	Video *VideoOptions // 录制会话内所有标签页的视频
+	Proxy *ProxyConfig  // 会话代理，为空时由浏览器的 ProxyProvider 分配，未设置分配器则直连
+
+	// BlockServiceWorkers 创建时即禁止注册 Service Worker(Playwright 的 serviceWorkers: block)，
+	// 同时启用 BlockServiceWorkers 预设以统计被屏蔽的注册
+	BlockServiceWorkers bool
+}
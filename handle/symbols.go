@@ -9,41 +9,334 @@ var Symbols = map[string]reflect.Value{
 	// 浏览器相关类型和方法
 	"TabPage": reflect.ValueOf((*TabPage)(nil)), // Export TabPage interface pointer type
 	"Browser": reflect.ValueOf((*Browser)(nil)), // Export Browser interface pointer type
+	"Session": reflect.ValueOf((*Session)(nil)), // Export Session interface pointer type
+	"Element": reflect.ValueOf((*Element)(nil)), // Export Element interface pointer type
+
+	// 元素相关错误
+	"ElementError":       reflect.ValueOf((*ElementError)(nil)),
+	"ErrElementNotFound": reflect.ValueOf(&ErrElementNotFound).Elem(),
+
+	// 等待相关类型
+	"ElementState":    reflect.ValueOf((*ElementState)(nil)),
+	"ElementAttached": reflect.ValueOf(ElementAttached),
+	"ElementVisible":  reflect.ValueOf(ElementVisible),
+	"ElementHidden":   reflect.ValueOf(ElementHidden),
+	"ElementDetached": reflect.ValueOf(ElementDetached),
+	"TimeoutError":    reflect.ValueOf((*TimeoutError)(nil)),
+
+	// 导航相关类型
+	"WaitUntil":            reflect.ValueOf((*WaitUntil)(nil)),
+	"WaitCommit":           reflect.ValueOf(WaitCommit),
+	"WaitDOMContentLoaded": reflect.ValueOf(WaitDOMContentLoaded),
+	"WaitLoad":             reflect.ValueOf(WaitLoad),
+	"WaitNetworkIdle":      reflect.ValueOf(WaitNetworkIdle),
+	"NavigateOptions":      reflect.ValueOf((*NavigateOptions)(nil)),
+	"NavigationResult":     reflect.ValueOf((*NavigationResult)(nil)),
+	"NavigationTimings":    reflect.ValueOf((*NavigationTimings)(nil)),
+	"RedirectHop":          reflect.ValueOf((*RedirectHop)(nil)),
+
+	// 函数绑定相关类型
+	"BindingCall": reflect.ValueOf((*BindingCall)(nil)),
+	"BindingFunc": reflect.ValueOf((*BindingFunc)(nil)),
+
+	// 数据提取相关类型
+	"Table": reflect.ValueOf((*Table)(nil)),
+
+	// 翻页相关类型
+	"PaginateMode":   reflect.ValueOf((*PaginateMode)(nil)),
+	"PaginateNext":   reflect.ValueOf(PaginateNext),
+	"PaginateURL":    reflect.ValueOf(PaginateURL),
+	"PaginateScroll": reflect.ValueOf(PaginateScroll),
+	"PageCursor":     reflect.ValueOf((*PageCursor)(nil)),
+
+	// 爬虫相关类型和方法
+	"Crawler":       reflect.ValueOf((*Crawler)(nil)),
+	"CrawlOptions":  reflect.ValueOf((*CrawlOptions)(nil)),
+	"CrawlRequest":  reflect.ValueOf((*CrawlRequest)(nil)),
+	"CrawlPage":     reflect.ValueOf((*CrawlPage)(nil)),
+	"CrawlStats":    reflect.ValueOf((*CrawlStats)(nil)),
+	"LinkExtractor": reflect.ValueOf((*LinkExtractor)(nil)),
+	"CrawlHandler":  reflect.ValueOf((*CrawlHandler)(nil)),
+	"NewCrawler":    reflect.ValueOf(NewCrawler),
+	"AnchorLinks":   reflect.ValueOf(AnchorLinks),
+	"RobotsRules":   reflect.ValueOf((*RobotsRules)(nil)),
+	"ParseRobots":   reflect.ValueOf(ParseRobots),
+
+	// 重试相关类型和方法
+	"RetryPolicy":        reflect.ValueOf((*RetryPolicy)(nil)),
+	"DefaultRetryPolicy": reflect.ValueOf(DefaultRetryPolicy),
+	"IsTransientError":   reflect.ValueOf(IsTransientError),
+	"IsNavigationError":  reflect.ValueOf(IsNavigationError),
+	"WithRetry":          reflect.ValueOf(WithRetry),
+	"Retry":              reflect.ValueOf(Retry),
+
+	// 请求拦截相关类型和方法
+	"RouteMatcher":        reflect.ValueOf((*RouteMatcher)(nil)),
+	"RouteAction":         reflect.ValueOf((*RouteAction)(nil)),
+	"RouteRequest":        reflect.ValueOf((*RouteRequest)(nil)),
+	"RouteResponse":       reflect.ValueOf((*RouteResponse)(nil)),
+	"RouteActionKind":     reflect.ValueOf((*RouteActionKind)(nil)),
+	"RouteActionAbort":    reflect.ValueOf(RouteActionAbort),
+	"RouteActionContinue": reflect.ValueOf(RouteActionContinue),
+	"RouteActionFulfill":  reflect.ValueOf(RouteActionFulfill),
+	"AbortRoute":          reflect.ValueOf(AbortRoute),
+	"ContinueRoute":       reflect.ValueOf(ContinueRoute),
+	"FulfillFile":         reflect.ValueOf(FulfillFile),
+	"FulfillFunc":         reflect.ValueOf(FulfillFunc),
+
+	// 响应捕获相关类型
+	"CapturedResponse": reflect.ValueOf((*CapturedResponse)(nil)),
+	"ResponseRecorder": reflect.ValueOf((*ResponseRecorder)(nil)),
+
+	// HAR 录制与回放相关类型
+	"HARContentMode":   reflect.ValueOf((*HARContentMode)(nil)),
+	"HARContentOmit":   reflect.ValueOf(HARContentOmit),
+	"HARContentEmbed":  reflect.ValueOf(HARContentEmbed),
+	"HAROptions":       reflect.ValueOf((*HAROptions)(nil)),
+	"HARReplayOptions": reflect.ValueOf((*HARReplayOptions)(nil)),
+	"HARRecorder":      reflect.ValueOf((*HARRecorder)(nil)),
+
+	// 下载管理相关类型
+	"DownloadManager":   reflect.ValueOf((*DownloadManager)(nil)),
+	"DownloadOptions":   reflect.ValueOf((*DownloadOptions)(nil)),
+	"DownloadEvent":     reflect.ValueOf((*DownloadEvent)(nil)),
+	"DownloadResult":    reflect.ValueOf((*DownloadResult)(nil)),
+	"DownloadCollision": reflect.ValueOf((*DownloadCollision)(nil)),
+	"DownloadRename":    reflect.ValueOf(DownloadRename),
+	"DownloadOverwrite": reflect.ValueOf(DownloadOverwrite),
+	"DownloadFail":      reflect.ValueOf(DownloadFail),
+	"DownloadState":     reflect.ValueOf((*DownloadState)(nil)),
+	"DownloadStarted":   reflect.ValueOf(DownloadStarted),
+	"DownloadProgress":  reflect.ValueOf(DownloadProgress),
+	"DownloadCompleted": reflect.ValueOf(DownloadCompleted),
+	"DownloadFailed":    reflect.ValueOf(DownloadFailed),
+	"DownloadCanceled":  reflect.ValueOf(DownloadCanceled),
+
+	// 文件上传相关类型和方法
+	"UploadFile":     reflect.ValueOf((*UploadFile)(nil)),
+	"FileFromPath":   reflect.ValueOf(FileFromPath),
+	"FileFromReader": reflect.ValueOf(FileFromReader),
+
+	// 对话框处理相关类型和方法
+	"DialogAction":   reflect.ValueOf((*DialogAction)(nil)),
+	"DialogAccept":   reflect.ValueOf(DialogAccept),
+	"DialogDismiss":  reflect.ValueOf(DialogDismiss),
+	"DialogInfo":     reflect.ValueOf((*DialogInfo)(nil)),
+	"DialogRecord":   reflect.ValueOf((*DialogRecord)(nil)),
+	"DialogPolicy":   reflect.ValueOf((*DialogPolicy)(nil)),
+	"AcceptDialogs":  reflect.ValueOf(AcceptDialogs),
+	"DismissDialogs": reflect.ValueOf(DismissDialogs),
+	"AnswerPrompts":  reflect.ValueOf(AnswerPrompts),
+	"DialogCallback": reflect.ValueOf(DialogCallback),
+
+	// 截图相关类型
+	"ScreenshotFormat":  reflect.ValueOf((*ScreenshotFormat)(nil)),
+	"ScreenshotPNG":     reflect.ValueOf(ScreenshotPNG),
+	"ScreenshotJPEG":    reflect.ValueOf(ScreenshotJPEG),
+	"ClipRect":          reflect.ValueOf((*ClipRect)(nil)),
+	"ScreenshotOptions": reflect.ValueOf((*ScreenshotOptions)(nil)),
+
+	// 失败现场采集相关类型
+	"ArtifactOptions": reflect.ValueOf((*ArtifactOptions)(nil)),
+	"ArtifactError":   reflect.ValueOf((*ArtifactError)(nil)),
+	"ConsoleMessage":  reflect.ValueOf((*ConsoleMessage)(nil)),
+
+	// 页面导出相关类型
+	"PDFOptions":     reflect.ValueOf((*PDFOptions)(nil)),
+	"PDFMargin":      reflect.ValueOf((*PDFMargin)(nil)),
+	"ErrUnsupported": reflect.ValueOf(&ErrUnsupported).Elem(),
+
+	// CDP 相关类型
+	"CDPSession":        reflect.ValueOf((*CDPSession)(nil)),
+	"CDPNetwork":        reflect.ValueOf((*CDPNetwork)(nil)),
+	"CDPEmulation":      reflect.ValueOf((*CDPEmulation)(nil)),
+	"CDPPerformance":    reflect.ValueOf((*CDPPerformance)(nil)),
+	"CDPInput":          reflect.ValueOf((*CDPInput)(nil)),
+	"NetworkConditions": reflect.ValueOf((*NetworkConditions)(nil)),
+	"DeviceMetrics":     reflect.ValueOf((*DeviceMetrics)(nil)),
+	"MouseEvent":        reflect.ValueOf((*MouseEvent)(nil)),
+	"KeyEvent":          reflect.ValueOf((*KeyEvent)(nil)),
+	"ModifierAlt":       reflect.ValueOf(ModifierAlt),
+	"ModifierCtrl":      reflect.ValueOf(ModifierCtrl),
+	"ModifierMeta":      reflect.ValueOf(ModifierMeta),
+	"ModifierShift":     reflect.ValueOf(ModifierShift),
+
+	// 网络模拟预设
+	"NetworkOffline": reflect.ValueOf(&NetworkOffline).Elem(),
+	"NetworkSlow3G":  reflect.ValueOf(&NetworkSlow3G).Elem(),
+	"NetworkFast3G":  reflect.ValueOf(&NetworkFast3G).Elem(),
+	"CustomNetwork":  reflect.ValueOf(CustomNetwork),
+
+	// 权限与地理位置相关类型
+	"Permission":               reflect.ValueOf((*Permission)(nil)),
+	"PermissionGeolocation":    reflect.ValueOf(PermissionGeolocation),
+	"PermissionNotifications":  reflect.ValueOf(PermissionNotifications),
+	"PermissionClipboardRead":  reflect.ValueOf(PermissionClipboardRead),
+	"PermissionClipboardWrite": reflect.ValueOf(PermissionClipboardWrite),
+	"PermissionCamera":         reflect.ValueOf(PermissionCamera),
+	"PermissionMicrophone":     reflect.ValueOf(PermissionMicrophone),
+	"PermissionSetting":        reflect.ValueOf((*PermissionSetting)(nil)),
+	"PermissionGranted":        reflect.ValueOf(PermissionGranted),
+	"PermissionDenied":         reflect.ValueOf(PermissionDenied),
+	"PermissionPrompt":         reflect.ValueOf(PermissionPrompt),
+	"Geolocation":              reflect.ValueOf((*Geolocation)(nil)),
+
+	// 代理相关类型
+	"ProxyConfig":       reflect.ValueOf((*ProxyConfig)(nil)),
+	"LaunchOptions":     reflect.ValueOf((*LaunchOptions)(nil)),
+	"ProxyProvider":     reflect.ValueOf((*ProxyProvider)(nil)),
+	"RoundRobinProxies": reflect.ValueOf(RoundRobinProxies),
+
+	// 会话与录制相关类型
+	"SessionOptions":  reflect.ValueOf((*SessionOptions)(nil)),
+	"TraceOptions":    reflect.ValueOf((*TraceOptions)(nil)),
+	"VideoOptions":    reflect.ValueOf((*VideoOptions)(nil)),
+	"RetentionPolicy": reflect.ValueOf((*RetentionPolicy)(nil)),
+	"RetainAlways":    reflect.ValueOf(RetainAlways),
+	"RetainOnFailure": reflect.ValueOf(RetainOnFailure),
+
+	// 资源屏蔽相关类型和方法
+	"BlockPreset":         reflect.ValueOf((*BlockPreset)(nil)),
+	"BlockImages":         reflect.ValueOf(BlockImages),
+	"BlockMedia":          reflect.ValueOf(BlockMedia),
+	"BlockFonts":          reflect.ValueOf(BlockFonts),
+	"BlockStylesheets":    reflect.ValueOf(BlockStylesheets),
+	"BlockTrackers":       reflect.ValueOf(BlockTrackers),
+	"BlockServiceWorkers": reflect.ValueOf(BlockServiceWorkers),
+	"AllBlockPresets":     reflect.ValueOf(AllBlockPresets),
+	"SetBlockedDomains":   reflect.ValueOf(SetBlockedDomains),
+	"LoadBlockedDomains":  reflect.ValueOf(LoadBlockedDomains),
+	"BlockedDomains":      reflect.ValueOf(BlockedDomains),
 
 	// Edge浏览器初始化方法
 	"Edge":                          reflect.ValueOf(Edge),                          // Export Edge function
 	"(*EdgeBrowserInstance).Listen": reflect.ValueOf((*EdgeBrowserInstance).Listen), // Export Listen method
+	"(*EdgeBrowserInstance).ListenWithOptions": reflect.ValueOf((*EdgeBrowserInstance).ListenWithOptions),
 
 	// TabPage的方法
-	"(*TabPage).ID":               reflect.ValueOf((*TabPage)(nil)).MethodByName("ID"),
-	"(*TabPage).Title":            reflect.ValueOf((*TabPage)(nil)).MethodByName("Title"),
-	"(*TabPage).URL":              reflect.ValueOf((*TabPage)(nil)).MethodByName("URL"),
-	"(*TabPage).Domain":           reflect.ValueOf((*TabPage)(nil)).MethodByName("Domain"),
-	"(*TabPage).Close":            reflect.ValueOf((*TabPage)(nil)).MethodByName("Close"),
-	"(*TabPage).IsClosed":         reflect.ValueOf((*TabPage)(nil)).MethodByName("IsClosed"),
-	"(*TabPage).BringToFront":     reflect.ValueOf((*TabPage)(nil)).MethodByName("BringToFront"),
-	"(*TabPage).OpenInNewTab":     reflect.ValueOf((*TabPage)(nil)).MethodByName("OpenInNewTab"),
-	"(*TabPage).WaitSelector":     reflect.ValueOf((*TabPage)(nil)).MethodByName("WaitSelector"),
-	"(*TabPage).QuerySelector":    reflect.ValueOf((*TabPage)(nil)).MethodByName("QuerySelector"),
-	"(*TabPage).QuerySelectorAll": reflect.ValueOf((*TabPage)(nil)).MethodByName("QuerySelectorAll"),
-	"(*TabPage).ClearLocalData":   reflect.ValueOf((*TabPage)(nil)).MethodByName("ClearLocalData"),
-	"(*TabPage).Goto":             reflect.ValueOf((*TabPage)(nil)).MethodByName("Goto"),
-	"(*TabPage).Evaluate":         reflect.ValueOf((*TabPage)(nil)).MethodByName("Evaluate"),
-	"(*TabPage).Page":             reflect.ValueOf((*TabPage)(nil)).MethodByName("Page"),
-	"(*TabPage).Reload":           reflect.ValueOf((*TabPage)(nil)).MethodByName("Reload"),
-	"(*TabPage).GetCookies":       reflect.ValueOf((*TabPage)(nil)).MethodByName("GetCookies"),
-	"(*TabPage).ApplyCookies":     reflect.ValueOf((*TabPage)(nil)).MethodByName("ApplyCookies"),
-	"(*TabPage).SleepRandom":      reflect.ValueOf((*TabPage)(nil)).MethodByName("SleepRandom"),
+	"(*TabPage).ID":                      reflect.ValueOf((*TabPage)(nil)).MethodByName("ID"),
+	"(*TabPage).Title":                   reflect.ValueOf((*TabPage)(nil)).MethodByName("Title"),
+	"(*TabPage).URL":                     reflect.ValueOf((*TabPage)(nil)).MethodByName("URL"),
+	"(*TabPage).Domain":                  reflect.ValueOf((*TabPage)(nil)).MethodByName("Domain"),
+	"(*TabPage).Close":                   reflect.ValueOf((*TabPage)(nil)).MethodByName("Close"),
+	"(*TabPage).IsClosed":                reflect.ValueOf((*TabPage)(nil)).MethodByName("IsClosed"),
+	"(*TabPage).BringToFront":            reflect.ValueOf((*TabPage)(nil)).MethodByName("BringToFront"),
+	"(*TabPage).OpenInNewTab":            reflect.ValueOf((*TabPage)(nil)).MethodByName("OpenInNewTab"),
+	"(*TabPage).WaitSelector":            reflect.ValueOf((*TabPage)(nil)).MethodByName("WaitSelector"),
+	"(*TabPage).QuerySelector":           reflect.ValueOf((*TabPage)(nil)).MethodByName("QuerySelector"),
+	"(*TabPage).QuerySelectorAll":        reflect.ValueOf((*TabPage)(nil)).MethodByName("QuerySelectorAll"),
+	"(*TabPage).ClearLocalData":          reflect.ValueOf((*TabPage)(nil)).MethodByName("ClearLocalData"),
+	"(*TabPage).Goto":                    reflect.ValueOf((*TabPage)(nil)).MethodByName("Goto"),
+	"(*TabPage).Navigate":                reflect.ValueOf((*TabPage)(nil)).MethodByName("Navigate"),
+	"(*TabPage).Retry":                   reflect.ValueOf((*TabPage)(nil)).MethodByName("Retry"),
+	"(*TabPage).Evaluate":                reflect.ValueOf((*TabPage)(nil)).MethodByName("Evaluate"),
+	"(*TabPage).EvaluateInto":            reflect.ValueOf((*TabPage)(nil)).MethodByName("EvaluateInto"),
+	"(*TabPage).CallModule":              reflect.ValueOf((*TabPage)(nil)).MethodByName("CallModule"),
+	"(*TabPage).ExposeFunc":              reflect.ValueOf((*TabPage)(nil)).MethodByName("ExposeFunc"),
+	"(*TabPage).Extract":                 reflect.ValueOf((*TabPage)(nil)).MethodByName("Extract"),
+	"(*TabPage).ExtractTable":            reflect.ValueOf((*TabPage)(nil)).MethodByName("ExtractTable"),
+	"(*TabPage).ExtractList":             reflect.ValueOf((*TabPage)(nil)).MethodByName("ExtractList"),
+	"(*TabPage).Page":                    reflect.ValueOf((*TabPage)(nil)).MethodByName("Page"),
+	"(*TabPage).Reload":                  reflect.ValueOf((*TabPage)(nil)).MethodByName("Reload"),
+	"(*TabPage).GetCookies":              reflect.ValueOf((*TabPage)(nil)).MethodByName("GetCookies"),
+	"(*TabPage).ApplyCookies":            reflect.ValueOf((*TabPage)(nil)).MethodByName("ApplyCookies"),
+	"(*TabPage).SleepRandom":             reflect.ValueOf((*TabPage)(nil)).MethodByName("SleepRandom"),
+	"(*TabPage).Route":                   reflect.ValueOf((*TabPage)(nil)).MethodByName("Route"),
+	"(*TabPage).Unroute":                 reflect.ValueOf((*TabPage)(nil)).MethodByName("Unroute"),
+	"(*TabPage).RouteNames":              reflect.ValueOf((*TabPage)(nil)).MethodByName("RouteNames"),
+	"(*TabPage).BlockResources":          reflect.ValueOf((*TabPage)(nil)).MethodByName("BlockResources"),
+	"(*TabPage).UnblockResources":        reflect.ValueOf((*TabPage)(nil)).MethodByName("UnblockResources"),
+	"(*TabPage).BlockedCounts":           reflect.ValueOf((*TabPage)(nil)).MethodByName("BlockedCounts"),
+	"(*TabPage).WaitForResponse":         reflect.ValueOf((*TabPage)(nil)).MethodByName("WaitForResponse"),
+	"(*TabPage).RecordResponses":         reflect.ValueOf((*TabPage)(nil)).MethodByName("RecordResponses"),
+	"(*TabPage).RecordHAR":               reflect.ValueOf((*TabPage)(nil)).MethodByName("RecordHAR"),
+	"(*TabPage).ReplayHAR":               reflect.ValueOf((*TabPage)(nil)).MethodByName("ReplayHAR"),
+	"(*TabPage).ExpectDownload":          reflect.ValueOf((*TabPage)(nil)).MethodByName("ExpectDownload"),
+	"(*TabPage).UploadFiles":             reflect.ValueOf((*TabPage)(nil)).MethodByName("UploadFiles"),
+	"(*TabPage).UploadFileData":          reflect.ValueOf((*TabPage)(nil)).MethodByName("UploadFileData"),
+	"(*TabPage).DropFiles":               reflect.ValueOf((*TabPage)(nil)).MethodByName("DropFiles"),
+	"(*TabPage).SetDialogPolicy":         reflect.ValueOf((*TabPage)(nil)).MethodByName("SetDialogPolicy"),
+	"(*TabPage).ClearDialogPolicy":       reflect.ValueOf((*TabPage)(nil)).MethodByName("ClearDialogPolicy"),
+	"(*TabPage).Dialogs":                 reflect.ValueOf((*TabPage)(nil)).MethodByName("Dialogs"),
+	"(*TabPage).Screenshot":              reflect.ValueOf((*TabPage)(nil)).MethodByName("Screenshot"),
+	"(*TabPage).EnableFailureArtifacts":  reflect.ValueOf((*TabPage)(nil)).MethodByName("EnableFailureArtifacts"),
+	"(*TabPage).DisableFailureArtifacts": reflect.ValueOf((*TabPage)(nil)).MethodByName("DisableFailureArtifacts"),
+	"(*TabPage).CaptureArtifacts":        reflect.ValueOf((*TabPage)(nil)).MethodByName("CaptureArtifacts"),
+	"(*TabPage).ConsoleMessages":         reflect.ValueOf((*TabPage)(nil)).MethodByName("ConsoleMessages"),
+	"(*TabPage).PDF":                     reflect.ValueOf((*TabPage)(nil)).MethodByName("PDF"),
+	"(*TabPage).MHTML":                   reflect.ValueOf((*TabPage)(nil)).MethodByName("MHTML"),
+	"(*TabPage).CDP":                     reflect.ValueOf((*TabPage)(nil)).MethodByName("CDP"),
+	"(*TabPage).EmulateNetwork":          reflect.ValueOf((*TabPage)(nil)).MethodByName("EmulateNetwork"),
+	"(*TabPage).ClearNetworkEmulation":   reflect.ValueOf((*TabPage)(nil)).MethodByName("ClearNetworkEmulation"),
+	"(*TabPage).SetCPUThrottling":        reflect.ValueOf((*TabPage)(nil)).MethodByName("SetCPUThrottling"),
+	"(*TabPage).SetTimezone":             reflect.ValueOf((*TabPage)(nil)).MethodByName("SetTimezone"),
+	"(*TabPage).SetLocale":               reflect.ValueOf((*TabPage)(nil)).MethodByName("SetLocale"),
+	"(*TabPage).WaitForElement":          reflect.ValueOf((*TabPage)(nil)).MethodByName("WaitForElement"),
+	"(*TabPage).WaitForText":             reflect.ValueOf((*TabPage)(nil)).MethodByName("WaitForText"),
+	"(*TabPage).WaitForURL":              reflect.ValueOf((*TabPage)(nil)).MethodByName("WaitForURL"),
+	"(*TabPage).WaitForURLRegex":         reflect.ValueOf((*TabPage)(nil)).MethodByName("WaitForURLRegex"),
+	"(*TabPage).WaitForNetworkIdle":      reflect.ValueOf((*TabPage)(nil)).MethodByName("WaitForNetworkIdle"),
+	"(*TabPage).WaitForFunction":         reflect.ValueOf((*TabPage)(nil)).MethodByName("WaitForFunction"),
+
+	// Element的方法
+	"(*Element).Selector":    reflect.ValueOf((*Element)(nil)).MethodByName("Selector"),
+	"(*Element).Click":       reflect.ValueOf((*Element)(nil)).MethodByName("Click"),
+	"(*Element).Fill":        reflect.ValueOf((*Element)(nil)).MethodByName("Fill"),
+	"(*Element).Text":        reflect.ValueOf((*Element)(nil)).MethodByName("Text"),
+	"(*Element).Attr":        reflect.ValueOf((*Element)(nil)).MethodByName("Attr"),
+	"(*Element).Visible":     reflect.ValueOf((*Element)(nil)).MethodByName("Visible"),
+	"(*Element).BoundingBox": reflect.ValueOf((*Element)(nil)).MethodByName("BoundingBox"),
+	"(*Element).Children":    reflect.ValueOf((*Element)(nil)).MethodByName("Children"),
+	"(*Element).Screenshot":  reflect.ValueOf((*Element)(nil)).MethodByName("Screenshot"),
 
 	// Browser的方法
-	"(*Browser).Name":            reflect.ValueOf((*Browser)(nil)).MethodByName("Name"),
-	"(*Browser).Port":            reflect.ValueOf((*Browser)(nil)).MethodByName("Port"),
-	"(*Browser).TabPages":        reflect.ValueOf((*Browser)(nil)).MethodByName("TabPages"),
-	"(*Browser).NewTabPage":      reflect.ValueOf((*Browser)(nil)).MethodByName("NewTabPage"),
-	"(*Browser).DefaultPage":     reflect.ValueOf((*Browser)(nil)).MethodByName("DefaultPage"),
-	"(*Browser).FindTabPage":     reflect.ValueOf((*Browser)(nil)).MethodByName("FindTabPage"),
-	"(*Browser).SwitchToTabPage": reflect.ValueOf((*Browser)(nil)).MethodByName("SwitchToTabPage"),
-	"(*Browser).CloseTabPage":    reflect.ValueOf((*Browser)(nil)).MethodByName("CloseTabPage"),
-	"(*Browser).IsAlive":         reflect.ValueOf((*Browser)(nil)).MethodByName("IsAlive"),
-	"(*Browser).Close":           reflect.ValueOf((*Browser)(nil)).MethodByName("Close"),
+	"(*Browser).Name":             reflect.ValueOf((*Browser)(nil)).MethodByName("Name"),
+	"(*Browser).Port":             reflect.ValueOf((*Browser)(nil)).MethodByName("Port"),
+	"(*Browser).TabPages":         reflect.ValueOf((*Browser)(nil)).MethodByName("TabPages"),
+	"(*Browser).NewTabPage":       reflect.ValueOf((*Browser)(nil)).MethodByName("NewTabPage"),
+	"(*Browser).DefaultPage":      reflect.ValueOf((*Browser)(nil)).MethodByName("DefaultPage"),
+	"(*Browser).Session":          reflect.ValueOf((*Browser)(nil)).MethodByName("Session"),
+	"(*Browser).NewSession":       reflect.ValueOf((*Browser)(nil)).MethodByName("NewSession"),
+	"(*Browser).FindSession":      reflect.ValueOf((*Browser)(nil)).MethodByName("FindSession"),
+	"(*Browser).Sessions":         reflect.ValueOf((*Browser)(nil)).MethodByName("Sessions"),
+	"(*Browser).SetProxyProvider": reflect.ValueOf((*Browser)(nil)).MethodByName("SetProxyProvider"),
+	"(*Browser).SetRetryPolicy":   reflect.ValueOf((*Browser)(nil)).MethodByName("SetRetryPolicy"),
+	"(*Browser).FindTabPage":      reflect.ValueOf((*Browser)(nil)).MethodByName("FindTabPage"),
+	"(*Browser).SwitchToTabPage":  reflect.ValueOf((*Browser)(nil)).MethodByName("SwitchToTabPage"),
+	"(*Browser).CloseTabPage":     reflect.ValueOf((*Browser)(nil)).MethodByName("CloseTabPage"),
+	"(*Browser).IsAlive":          reflect.ValueOf((*Browser)(nil)).MethodByName("IsAlive"),
+	"(*Browser).Close":            reflect.ValueOf((*Browser)(nil)).MethodByName("Close"),
+	"(*Browser).SetDialogPolicy":  reflect.ValueOf((*Browser)(nil)).MethodByName("SetDialogPolicy"),
+	"(*Browser).Dialogs":          reflect.ValueOf((*Browser)(nil)).MethodByName("Dialogs"),
+
+	// Session的方法
+	"(*Session).ID":                    reflect.ValueOf((*Session)(nil)).MethodByName("ID"),
+	"(*Session).Context":               reflect.ValueOf((*Session)(nil)).MethodByName("Context"),
+	"(*Session).Proxy":                 reflect.ValueOf((*Session)(nil)).MethodByName("Proxy"),
+	"(*Session).Route":                 reflect.ValueOf((*Session)(nil)).MethodByName("Route"),
+	"(*Session).Unroute":               reflect.ValueOf((*Session)(nil)).MethodByName("Unroute"),
+	"(*Session).RouteNames":            reflect.ValueOf((*Session)(nil)).MethodByName("RouteNames"),
+	"(*Session).BlockResources":        reflect.ValueOf((*Session)(nil)).MethodByName("BlockResources"),
+	"(*Session).UnblockResources":      reflect.ValueOf((*Session)(nil)).MethodByName("UnblockResources"),
+	"(*Session).BlockedCounts":         reflect.ValueOf((*Session)(nil)).MethodByName("BlockedCounts"),
+	"(*Session).RecordHAR":             reflect.ValueOf((*Session)(nil)).MethodByName("RecordHAR"),
+	"(*Session).ReplayHAR":             reflect.ValueOf((*Session)(nil)).MethodByName("ReplayHAR"),
+	"(*Session).Downloads":             reflect.ValueOf((*Session)(nil)).MethodByName("Downloads"),
+	"(*Session).NewTabPage":            reflect.ValueOf((*Session)(nil)).MethodByName("NewTabPage"),
+	"(*Session).TabPages":              reflect.ValueOf((*Session)(nil)).MethodByName("TabPages"),
+	"(*Session).StartTracing":          reflect.ValueOf((*Session)(nil)).MethodByName("StartTracing"),
+	"(*Session).MarkFailed":            reflect.ValueOf((*Session)(nil)).MethodByName("MarkFailed"),
+	"(*Session).Recordings":            reflect.ValueOf((*Session)(nil)).MethodByName("Recordings"),
+	"(*Session).Close":                 reflect.ValueOf((*Session)(nil)).MethodByName("Close"),
+	"(*Session).EmulateNetwork":        reflect.ValueOf((*Session)(nil)).MethodByName("EmulateNetwork"),
+	"(*Session).ClearNetworkEmulation": reflect.ValueOf((*Session)(nil)).MethodByName("ClearNetworkEmulation"),
+	"(*Session).SetCPUThrottling":      reflect.ValueOf((*Session)(nil)).MethodByName("SetCPUThrottling"),
+	"(*Session).GrantPermissions":      reflect.ValueOf((*Session)(nil)).MethodByName("GrantPermissions"),
+	"(*Session).DenyPermissions":       reflect.ValueOf((*Session)(nil)).MethodByName("DenyPermissions"),
+	"(*Session).ClearPermissions":      reflect.ValueOf((*Session)(nil)).MethodByName("ClearPermissions"),
+	"(*Session).SetGeolocation":        reflect.ValueOf((*Session)(nil)).MethodByName("SetGeolocation"),
+	"(*Session).SetTimezone":           reflect.ValueOf((*Session)(nil)).MethodByName("SetTimezone"),
+	"(*Session).SetLocale":             reflect.ValueOf((*Session)(nil)).MethodByName("SetLocale"),
+	"(*Session).RegisterModule":        reflect.ValueOf((*Session)(nil)).MethodByName("RegisterModule"),
+	"(*Session).ExposeFunc":            reflect.ValueOf((*Session)(nil)).MethodByName("ExposeFunc"),
 }
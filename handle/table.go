@@ -0,0 +1,177 @@
+package handle
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// tableScript 将表格展开为二维文本网格，合并单元格的值填充到其覆盖的每个位置；
+// table.rows 按 thead、tbody、tfoot 的顺序排列
+const tableScript = `(selector) => {
+	const table = document.querySelector(selector);
+	if (!table) return null;
+	if (table.tagName !== "TABLE") throw new Error(selector + " 不是 table 元素");
+	const text = (cell) => (cell.innerText ?? cell.textContent ?? "").replace(/\s+/g, " ").trim();
+	const rows = Array.from(table.rows);
+	const grid = rows.map(() => []);
+	rows.forEach((row, r) => {
+		let c = 0;
+		for (const cell of row.cells) {
+			while (grid[r][c] !== undefined) c++;
+			const value = text(cell);
+			const colspan = Math.max(cell.colSpan || 1, 1);
+			const section = row.parentElement;
+			const sectionEnd = rows.findLastIndex(other => other.parentElement === section) + 1;
+			const rowspan = cell.rowSpan === 0 ? sectionEnd - r : Math.max(cell.rowSpan || 1, 1);
+			for (let i = 0; i < rowspan && r + i < rows.length; i++) {
+				for (let j = 0; j < colspan; j++) {
+					grid[r + i][c + j] = value;
+				}
+			}
+			c += colspan;
+		}
+	});
+	let header = table.tHead ? table.tHead.rows.length : 0;
+	if (header === 0) {
+		// 没有 thead 时，开头全部由 th 组成的行视为表头
+		while (header < rows.length && rows[header].cells.length > 0 &&
+			Array.from(rows[header].cells).every(cell => cell.tagName === "TH")) header++;
+	}
+	const footer = table.tFoot ? table.tFoot.rows.length : 0;
+	return {
+		grid: grid.map(row => Array.from(row, value => value ?? "")),
+		header: header,
+		footer: Math.min(footer, rows.length - header),
+	};
+}`
+
+// listScript 读取列表直接子元素的文本，ul/ol 只取 li
+const listScript = `(selector) => {
+	const list = document.querySelector(selector);
+	if (!list) return null;
+	const items = ["UL", "OL"].includes(list.tagName)
+		? Array.from(list.children).filter(el => el.tagName === "LI")
+		: Array.from(list.children);
+	return items.map(el => (el.innerText ?? el.textContent ?? "").replace(/\s+/g, " ").trim());
+}`
+
+// Table 从页面表格中提取的数据，合并单元格已展开，每行列数相同
+type Table struct {
+	Headers []string   // 列名，多行表头按 " / " 合并，空列名为 "列N"，重复列名追加序号
+	Rows    [][]string // tbody 中的数据行
+	Footer  [][]string // tfoot 中的行
+}
+
+// tableGrid tableScript 的返回值
+type tableGrid struct {
+	Grid   [][]string `json:"grid"`
+	Header int        `json:"header"`
+	Footer int        `json:"footer"`
+}
+
+// new_table 根据展开后的网格生成 Table，补齐每行的列数
+func new_table(g tableGrid) *Table {
+	width := 0
+	for _, row := range g.Grid {
+		width = max(width, len(row))
+	}
+	for i, row := range g.Grid {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		g.Grid[i] = row
+	}
+
+	header := min(max(g.Header, 0), len(g.Grid))
+	footer := min(max(g.Footer, 0), len(g.Grid)-header)
+	return &Table{
+		Headers: table_headers(g.Grid[:header], width),
+		Rows:    g.Grid[header : len(g.Grid)-footer],
+		Footer:  g.Grid[len(g.Grid)-footer:],
+	}
+}
+
+// table_headers 合并多行表头，跨列单元格在同一列重复出现的值只保留一次
+func table_headers(rows [][]string, width int) []string {
+	headers := make([]string, width)
+	seen := make(map[string]int, width)
+	for c := 0; c < width; c++ {
+		parts := make([]string, 0, len(rows))
+		for _, row := range rows {
+			if value := row[c]; value != "" && (len(parts) == 0 || parts[len(parts)-1] != value) {
+				parts = append(parts, value)
+			}
+		}
+		name := strings.Join(parts, " / ")
+		if name == "" {
+			name = "列" + strconv.Itoa(c+1)
+		}
+		seen[name]++
+		if n := seen[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+		headers[c] = name
+	}
+	return headers
+}
+
+// Records 按列名将每个数据行转换为 map
+func (t *Table) Records() []map[string]string {
+	records := make([]map[string]string, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		record := make(map[string]string, len(t.Headers))
+		for i, name := range t.Headers {
+			if i < len(row) {
+				record[name] = row[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// WriteCSV 输出表头和数据行，不包含 tfoot
+func (t *Table) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if len(t.Headers) > 0 {
+		if err := writer.Write(t.Headers); err != nil {
+			return fmt.Errorf("无法输出 CSV: %w", err)
+		}
+	}
+	if err := writer.WriteAll(t.Rows); err != nil {
+		return fmt.Errorf("无法输出 CSV: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON 按 Records 输出 JSON 数组，键按表头顺序排列
+func (t *Table) WriteJSON(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("[")
+	for r, row := range t.Rows {
+		if r > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("{")
+		for i, name := range t.Headers {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			key, _ := json.Marshal(name)
+			value, _ := json.Marshal(row[i])
+			b.Write(key)
+			b.WriteString(":")
+			b.Write(value)
+		}
+		b.WriteString("}")
+	}
+	b.WriteString("]\n")
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("无法输出 JSON: %w", err)
+	}
+	return nil
+}
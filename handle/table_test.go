@@ -0,0 +1,90 @@
+package handle
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestNewTable(t *testing.T) {
+	cases := []struct {
+		name    string
+		grid    tableGrid
+		headers []string
+		rows    [][]string
+		footer  [][]string
+	}{
+		{
+			name:    "单行表头",
+			grid:    tableGrid{Grid: [][]string{{"名称", "价格"}, {"A", "1"}, {"B", "2"}}, Header: 1},
+			headers: []string{"名称", "价格"},
+			rows:    [][]string{{"A", "1"}, {"B", "2"}},
+		},
+		{
+			name: "多行表头合并，跨列的值只保留一次",
+			grid: tableGrid{Grid: [][]string{
+				{"商品", "价格", "价格"},
+				{"商品", "原价", "现价"},
+				{"A", "10", "8"},
+			}, Header: 2},
+			headers: []string{"商品", "价格 / 原价", "价格 / 现价"},
+			rows:    [][]string{{"A", "10", "8"}},
+		},
+		{
+			name:    "空列名和重复列名",
+			grid:    tableGrid{Grid: [][]string{{"", "值", "值"}, {"1", "2", "3"}}, Header: 1},
+			headers: []string{"列1", "值", "值_2"},
+			rows:    [][]string{{"1", "2", "3"}},
+		},
+		{
+			name:    "补齐列数并拆出 tfoot",
+			grid:    tableGrid{Grid: [][]string{{"a", "b"}, {"1"}, {"合计", "1"}}, Header: 1, Footer: 1},
+			headers: []string{"a", "b"},
+			rows:    [][]string{{"1", ""}},
+			footer:  [][]string{{"合计", "1"}},
+		},
+		{
+			name:    "没有表头",
+			grid:    tableGrid{Grid: [][]string{{"1", "2"}}},
+			headers: []string{"列1", "列2"},
+			rows:    [][]string{{"1", "2"}},
+		},
+		{
+			name:    "越界的行数被截断",
+			grid:    tableGrid{Grid: [][]string{{"h"}, {"f"}}, Header: 5, Footer: 5},
+			headers: []string{"h / f"},
+		},
+	}
+	for _, c := range cases {
+		table := new_table(c.grid)
+		if !slices.Equal(table.Headers, c.headers) {
+			t.Errorf("%s: 表头为 %q，期望 %q", c.name, table.Headers, c.headers)
+		}
+		if !slices.EqualFunc(table.Rows, c.rows, slices.Equal) {
+			t.Errorf("%s: 数据行为 %q，期望 %q", c.name, table.Rows, c.rows)
+		}
+		if !slices.EqualFunc(table.Footer, c.footer, slices.Equal) {
+			t.Errorf("%s: 表尾为 %q，期望 %q", c.name, table.Footer, c.footer)
+		}
+	}
+}
+
+func TestTableOutput(t *testing.T) {
+	table := new_table(tableGrid{Grid: [][]string{{"名称", "备注"}, {"A", `含"引号", 逗号`}}, Header: 1})
+
+	var csv bytes.Buffer
+	if err := table.WriteCSV(&csv); err != nil {
+		t.Fatalf("输出 CSV 失败: %v", err)
+	}
+	if want := "名称,备注\nA,\"含\"\"引号\"\", 逗号\"\n"; csv.String() != want {
+		t.Errorf("CSV 输出为 %q，期望 %q", csv.String(), want)
+	}
+
+	var json bytes.Buffer
+	if err := table.WriteJSON(&json); err != nil {
+		t.Fatalf("输出 JSON 失败: %v", err)
+	}
+	if want := `[{"名称":"A","备注":"含\"引号\", 逗号"}]` + "\n"; json.String() != want {
+		t.Errorf("JSON 输出为 %q，期望 %q", json.String(), want)
+	}
+}
@@ -0,0 +1,124 @@
+package handle_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+type bindingEvent struct {
+	Seq  int    `json:"seq"`
+	Kind string `json:"kind"`
+}
+
+func TestExposeFuncAndBind(t *testing.T) {
+	page := browser.NewTabPage("binding", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	var (
+		locker sync.Mutex
+		seqs   []int
+	)
+	err := page.ExposeFunc("goRecord", handle.Bind(func(event bindingEvent) (int, error) {
+		if event.Kind == "" {
+			return 0, errors.New("缺少事件类型")
+		}
+		locker.Lock()
+		defer locker.Unlock()
+		seqs = append(seqs, event.Seq)
+		return event.Seq * 10, nil
+	}))
+	if err != nil {
+		t.Fatalf("暴露函数失败: %v", err)
+	}
+	err = page.ExposeFunc("goTab", func(call *handle.BindingCall) (any, error) {
+		var prefix string
+		if err := call.Arg(0, &prefix); err != nil {
+			return nil, err
+		}
+		return prefix + call.TabID, nil
+	})
+	if err != nil {
+		t.Fatalf("暴露函数失败: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 并发调用时到达顺序不确定，只校验每次调用的返回值和调用集合
+	var results []int
+	err = page.EvaluateInto(ctx, &results, `async () => {
+		const calls = [];
+		for (let i = 1; i <= 5; i++) calls.push(window.goRecord({ seq: i, kind: "added" }));
+		return Promise.all(calls);
+	}`)
+	if err != nil {
+		t.Fatalf("调用暴露函数失败: %v", err)
+	}
+	if !slices.Equal(results, []int{10, 20, 30, 40, 50}) {
+		t.Fatalf("返回值异常: %v", results)
+	}
+	locker.Lock()
+	slices.Sort(seqs)
+	received := slices.Clone(seqs)
+	locker.Unlock()
+	if !slices.Equal(received, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("Go 收到的调用异常: %v", received)
+	}
+
+	var message string
+	err = page.EvaluateInto(ctx, &message, `() => window.goRecord({ seq: 6 }).then(() => "resolved", err => err.message)`)
+	if err != nil {
+		t.Fatalf("调用暴露函数失败: %v", err)
+	}
+	if message == "resolved" || message == "" {
+		t.Fatalf("函数返回错误时 Promise 应被 reject: %q", message)
+	}
+
+	var tab string
+	if err := page.EvaluateInto(ctx, &tab, `() => window.goTab("tab:")`); err != nil {
+		t.Fatalf("调用暴露函数失败: %v", err)
+	}
+	if tab != "tab:binding" {
+		t.Fatalf("调用来源的标签页ID异常: %q", tab)
+	}
+}
+
+func TestSessionExposeFuncTabID(t *testing.T) {
+	session, err := browser.NewSession("binding-session", handle.SessionOptions{})
+	if err != nil {
+		t.Fatalf("创建会话失败: %v", err)
+	}
+	defer session.Close()
+
+	err = session.ExposeFunc("goSessionTab", func(call *handle.BindingCall) (any, error) {
+		return call.TabID, nil
+	})
+	if err != nil {
+		t.Fatalf("暴露函数失败: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, id := range []string{"session-tab-a", "session-tab-b"} {
+		page := session.NewTabPage(id, "about:blank")
+		if page == nil {
+			t.Fatalf("创建标签页失败")
+		}
+		var tab string
+		if err := page.EvaluateInto(ctx, &tab, `() => window.goSessionTab()`); err != nil {
+			t.Fatalf("调用暴露函数失败: %v", err)
+		}
+		if tab != id {
+			t.Fatalf("调用来源的标签页ID异常: %q, 期望 %q", tab, id)
+		}
+	}
+}
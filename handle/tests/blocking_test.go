@@ -0,0 +1,109 @@
+package handle_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func new_blocking_server() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><img id="logo" src="/logo.png"></body></html>`)
+		case "/logo.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("\x89PNG\r\n\x1a\n"))
+		case "/sw.js":
+			w.Header().Set("Content-Type", "text/javascript")
+			fmt.Fprint(w, `self.addEventListener("fetch", () => {});`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestBlockResourcesCountsAcrossUnblock(t *testing.T) {
+	server := new_blocking_server()
+	defer server.Close()
+
+	page := browser.NewTabPage("block-images", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	// 先注册的放行规则不能绕过屏蔽
+	if err := page.Route("pass-all", handle.RouteMatcher{URLGlob: "**/*"}, handle.ContinueRoute(nil, nil, nil)); err != nil {
+		t.Fatalf("添加路由规则失败: %v", err)
+	}
+	if err := page.BlockResources(handle.BlockImages); err != nil {
+		t.Fatalf("屏蔽图片失败: %v", err)
+	}
+	if err := page.Goto(server.URL); err != nil {
+		t.Fatalf("访问页面失败: %v", err)
+	}
+	if n := page.BlockedCounts()[handle.BlockImages]; n != 1 {
+		t.Fatalf("屏蔽计数异常: %d", n)
+	}
+
+	if err := page.UnblockResources(handle.BlockImages); err != nil {
+		t.Fatalf("取消屏蔽失败: %v", err)
+	}
+	if err := page.BlockResources(handle.BlockImages); err != nil {
+		t.Fatalf("再次屏蔽图片失败: %v", err)
+	}
+	if err := page.Goto(server.URL + "/?again"); err != nil {
+		t.Fatalf("访问页面失败: %v", err)
+	}
+	if n := page.BlockedCounts()[handle.BlockImages]; n != 2 {
+		t.Fatalf("再次屏蔽后计数未累计: %d", n)
+	}
+
+	err := page.Route("block:images", handle.RouteMatcher{URLGlob: "**/*"}, handle.AbortRoute(""))
+	if err == nil {
+		t.Fatalf("保留前缀的规则名称应被拒绝")
+	}
+}
+
+func TestBlockServiceWorkers(t *testing.T) {
+	server := new_blocking_server()
+	defer server.Close()
+
+	session, err := browser.NewSession("block-service-workers", handle.SessionOptions{BlockServiceWorkers: true})
+	if err != nil {
+		t.Fatalf("创建会话失败: %v", err)
+	}
+	defer session.Close()
+
+	page := session.NewTabPage("block-service-workers", server.URL)
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	result, err := page.Evaluate(`async () => {
+		try {
+			await navigator.serviceWorker.register("/sw.js");
+			return "registered";
+		} catch (e) {
+			return e.name;
+		}
+	}`)
+	if err != nil {
+		t.Fatalf("执行脚本失败: %v", err)
+	}
+	if name, _ := result.(string); name != "SecurityError" {
+		t.Fatalf("Service Worker 注册未被拒绝: %v", result)
+	}
+	if n := session.BlockedCounts()[handle.BlockServiceWorkers]; n != 1 {
+		t.Fatalf("Service Worker 屏蔽计数异常: %d", n)
+	}
+
+	err = session.UnblockResources(handle.BlockServiceWorkers)
+	if !errors.Is(err, handle.ErrUnsupported) {
+		t.Fatalf("取消 Service Worker 屏蔽应返回错误: %v", err)
+	}
+}
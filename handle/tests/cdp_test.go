@@ -0,0 +1,72 @@
+package handle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCDPSessionLifecycle(t *testing.T) {
+	page := browser.NewTabPage("cdp", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cdp, err := page.CDP()
+	if err != nil {
+		t.Fatalf("创建 CDP 会话失败: %v", err)
+	}
+	if again, _ := page.CDP(); again != cdp {
+		t.Fatalf("CDP 会话未被缓存")
+	}
+
+	var result struct {
+		Result struct {
+			Value float64 `json:"value"`
+		} `json:"result"`
+	}
+	if err := cdp.SendAs(ctx, "Runtime.evaluate", map[string]any{"expression": "6 * 7"}, &result); err != nil {
+		t.Fatalf("发送 CDP 命令失败: %v", err)
+	}
+	if result.Result.Value != 42 {
+		t.Fatalf("CDP 命令结果异常: %v", result.Result.Value)
+	}
+
+	events := make(chan string, 4)
+	unsubscribe := cdp.On("Runtime.consoleAPICalled", func(params map[string]any) {
+		kind, _ := params["type"].(string)
+		events <- kind
+	})
+	defer unsubscribe()
+	if err := cdp.Enable(ctx, "Runtime"); err != nil {
+		t.Fatalf("启用 Runtime 域失败: %v", err)
+	}
+	if _, err := page.Evaluate("() => console.warn('cdp')"); err != nil {
+		t.Fatalf("执行脚本失败: %v", err)
+	}
+	select {
+	case kind := <-events:
+		if kind != "warning" {
+			t.Fatalf("事件类型异常: %s", kind)
+		}
+	case <-ctx.Done():
+		t.Fatalf("未收到 CDP 事件")
+	}
+
+	if err := cdp.Detach(); err != nil {
+		t.Fatalf("断开 CDP 会话失败: %v", err)
+	}
+	renewed, err := page.CDP()
+	if err != nil || renewed == cdp {
+		t.Fatalf("断开后应重新创建 CDP 会话: %v", err)
+	}
+
+	page.Close()
+	if _, err := page.CDP(); err == nil {
+		t.Fatalf("标签页关闭后不应返回 CDP 会话")
+	}
+}
@@ -0,0 +1,72 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestCrawlerLocalSite(t *testing.T) {
+	pages := map[string]string{
+		"/":          `<a href="/a">A</a><a href="/b#top">B</a><a href="/private/x">私有</a><a href="https://example.com/">外部</a>`,
+		"/a":         `<a href="b">B</a><a href="/c?y=2&x=1">C</a>`,
+		"/b":         `<a href="/">首页</a>`,
+		"/c":         `<a href="/d" rel="nofollow">D</a>`,
+		"/private/x": `私有页面`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+			return
+		}
+		body, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, "<html><head><title>%s</title></head><body>%s</body></html>", r.URL.Path, body)
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var titles []string
+	crawler, err := handle.NewCrawler(browser.Session(), handle.CrawlOptions{
+		Seeds:         []string{server.URL + "/"},
+		SameDomain:    true,
+		RespectRobots: true,
+		DomainDelay:   50 * time.Millisecond,
+		Handler: func(ctx context.Context, page *handle.CrawlPage) error {
+			mu.Lock()
+			titles = append(titles, page.Tab.Title())
+			mu.Unlock()
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("创建爬虫失败: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	stats, err := crawler.Run(ctx)
+	if err != nil {
+		t.Fatalf("抓取失败: %v", err)
+	}
+
+	sort.Strings(titles)
+	if strings.Join(titles, ",") != "/,/a,/b,/c" {
+		t.Fatalf("抓取的页面异常: %v", titles)
+	}
+	if stats.Visited != 4 || stats.Disallowed != 1 || stats.Failed != 0 {
+		t.Fatalf("抓取统计异常: %+v", stats)
+	}
+}
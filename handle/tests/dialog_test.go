@@ -0,0 +1,54 @@
+package handle_test
+
+import (
+	"testing"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestDialogPolicy(t *testing.T) {
+	page := browser.NewTabPage("dialog", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	page.SetDialogPolicy(handle.AnswerPrompts("答案"))
+	result, err := page.Evaluate(`() => [confirm("确认吗"), prompt("请输入", "默认")]`)
+	if err != nil {
+		t.Fatalf("弹出对话框失败: %v", err)
+	}
+	if values, ok := result.([]any); !ok || len(values) != 2 || values[0] != true || values[1] != "答案" {
+		t.Fatalf("确认策略的结果异常: %v", result)
+	}
+
+	page.SetDialogPolicy(handle.DismissDialogs())
+	result, err = page.Evaluate(`() => [confirm("确认吗"), prompt("请输入", "默认")]`)
+	if err != nil {
+		t.Fatalf("弹出对话框失败: %v", err)
+	}
+	if values, ok := result.([]any); !ok || len(values) != 2 || values[0] != false || values[1] != nil {
+		t.Fatalf("取消策略的结果异常: %v", result)
+	}
+
+	page.SetDialogPolicy(handle.DialogCallback(func(info handle.DialogInfo) (handle.DialogAction, string) {
+		if info.Type == "prompt" {
+			return handle.DialogAccept, info.DefaultValue + "+回调"
+		}
+		return handle.DialogDismiss, ""
+	}))
+	result, err = page.Evaluate(`() => prompt("请输入", "默认")`)
+	if err != nil || result != "默认+回调" {
+		t.Fatalf("回调策略的结果异常: %v, %v", result, err)
+	}
+
+	records := page.Dialogs()
+	if len(records) != 5 {
+		t.Fatalf("对话框记录数异常: %d", len(records))
+	}
+	last := records[len(records)-1]
+	if last.Type != "prompt" || last.Message != "请输入" || last.TabID != "dialog" ||
+		last.Action != handle.DialogAccept || last.PromptText != "默认+回调" {
+		t.Fatalf("对话框记录异常: %+v", last)
+	}
+}
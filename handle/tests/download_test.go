@@ -0,0 +1,81 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestDownloadToDirWithRename(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><a id="report" href="/report.csv">下载</a></body></html>`)
+		case "/report.csv":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", `attachment; filename="report.csv"`)
+			fmt.Fprint(w, "name,count\na,1\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	session, err := browser.NewSession("download", handle.SessionOptions{})
+	if err != nil {
+		t.Fatalf("创建会话失败: %v", err)
+	}
+	defer session.Close()
+
+	dir := t.TempDir()
+	session.Downloads().SetOptions(handle.DownloadOptions{Dir: dir})
+	completed := make(chan string, 4)
+	unsubscribe := session.Downloads().OnEvent(func(event handle.DownloadEvent) {
+		if event.State == handle.DownloadCompleted {
+			completed <- event.Path
+		}
+	})
+	defer unsubscribe()
+
+	page := session.NewTabPage("download", server.URL)
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	click := func() error {
+		_, err := page.Evaluate(`() => document.getElementById("report").click()`)
+		return err
+	}
+	wants := []string{"report.csv", "report (1).csv"}
+	for _, want := range wants {
+		result, err := page.ExpectDownload(ctx, click)
+		if err != nil {
+			t.Fatalf("下载失败: %v", err)
+		}
+		if result.Path != filepath.Join(dir, want) {
+			t.Fatalf("保存路径异常: %s, 期望 %s", result.Path, want)
+		}
+		data, err := os.ReadFile(result.Path)
+		if err != nil || string(data) != "name,count\na,1\n" || result.Size != int64(len(data)) {
+			t.Fatalf("下载内容异常: %q, %d, %v", data, result.Size, err)
+		}
+	}
+
+	// 事件订阅者与 ExpectDownload 的等待并发执行，逐个等待完成事件
+	for range wants {
+		select {
+		case <-completed:
+		case <-ctx.Done():
+			t.Fatalf("未收到下载完成事件")
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package handle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestClickMissingElementDoesNotRetry(t *testing.T) {
+	page := browser.NewTabPage("click-missing", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	if _, err := page.Evaluate(`() => { document.body.innerHTML = '<button id="gone">按钮</button>'; }`); err != nil {
+		t.Fatalf("准备页面失败: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	element, err := page.WaitForElement(ctx, "#gone", handle.ElementAttached)
+	if err != nil {
+		t.Fatalf("等待元素失败: %v", err)
+	}
+	if _, err := page.Evaluate(`() => document.getElementById("gone").remove()`); err != nil {
+		t.Fatalf("移除元素失败: %v", err)
+	}
+
+	retries := 0
+	policy := handle.RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   10 * time.Millisecond,
+		Retryable:   func(err error) bool { return true },
+		OnRetry:     func(attempt int, err error, delay time.Duration) { retries++ },
+	}
+	clickCtx, clickCancel := context.WithTimeout(handle.WithRetry(context.Background(), policy), time.Second)
+	defer clickCancel()
+	err = element.Click(clickCtx)
+	if !errors.Is(err, handle.ErrElementNotFound) {
+		t.Fatalf("点击不存在的元素应返回 ErrElementNotFound: %v", err)
+	}
+	if retries != 0 {
+		t.Fatalf("元素不存在时不应重试，实际重试 %d 次", retries)
+	}
+}
@@ -0,0 +1,97 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestEmulateNetwork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprint(w, `<html><body>emulation</body></html>`)
+	}))
+	defer server.Close()
+
+	page := browser.NewTabPage("emulation", server.URL)
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// 返回请求耗时(毫秒)，请求失败时返回 -1
+	const timedFetch = `async () => {
+		const started = performance.now();
+		try {
+			await fetch("/ping?" + Math.random(), { cache: "no-store" });
+		} catch (e) {
+			return -1;
+		}
+		return performance.now() - started;
+	}`
+	var elapsed float64
+
+	if err := page.EmulateNetwork(ctx, handle.NetworkOffline); err != nil {
+		t.Fatalf("模拟离线失败: %v", err)
+	}
+	if err := page.EvaluateInto(ctx, &elapsed, timedFetch); err != nil {
+		t.Fatalf("执行请求失败: %v", err)
+	}
+	if elapsed != -1 {
+		t.Fatalf("离线时请求应失败，实际耗时 %vms", elapsed)
+	}
+
+	if err := page.EmulateNetwork(ctx, handle.CustomNetwork(600*time.Millisecond, 0, 0)); err != nil {
+		t.Fatalf("模拟延迟失败: %v", err)
+	}
+	if err := page.EvaluateInto(ctx, &elapsed, timedFetch); err != nil {
+		t.Fatalf("执行请求失败: %v", err)
+	}
+	if elapsed < 500 {
+		t.Fatalf("模拟延迟未生效，耗时 %vms", elapsed)
+	}
+
+	if err := page.ClearNetworkEmulation(ctx); err != nil {
+		t.Fatalf("取消网络模拟失败: %v", err)
+	}
+	if err := page.EvaluateInto(ctx, &elapsed, timedFetch); err != nil {
+		t.Fatalf("执行请求失败: %v", err)
+	}
+	if elapsed < 0 || elapsed >= 500 {
+		t.Fatalf("取消模拟后请求耗时异常: %vms", elapsed)
+	}
+}
+
+func TestSessionNetworkEmulationAppliesToNewTabs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body>emulation</body></html>`)
+	}))
+	defer server.Close()
+
+	session, err := browser.NewSession("emulation", handle.SessionOptions{})
+	if err != nil {
+		t.Fatalf("创建会话失败: %v", err)
+	}
+	defer session.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := session.EmulateNetwork(ctx, handle.NetworkOffline); err != nil {
+		t.Fatalf("模拟离线失败: %v", err)
+	}
+	page := session.NewTabPage("emulation", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	if err := page.Goto(server.URL); err == nil {
+		t.Fatalf("会话离线时新标签页的导航应失败")
+	}
+}
@@ -0,0 +1,52 @@
+package handle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+type priceQuery struct {
+	Base     float64 `json:"base"`
+	Discount float64 `json:"discount"`
+}
+
+type priceResult struct {
+	Total float64  `json:"total"`
+	Tags  []string `json:"tags"`
+}
+
+func TestEvaluateAsAndCallModule(t *testing.T) {
+	page := browser.NewTabPage("evaluate", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := handle.EvaluateAs[priceResult](ctx, page,
+		"(q, tag) => ({ total: q.base * (1 - q.discount), tags: [tag] })",
+		priceQuery{Base: 200, Discount: 0.25}, "sale")
+	if err != nil {
+		t.Fatalf("执行脚本失败: %v", err)
+	}
+	if result.Total != 150 || len(result.Tags) != 1 || result.Tags[0] != "sale" {
+		t.Fatalf("脚本返回值异常: %+v", result)
+	}
+
+	err = browser.Session().RegisterModule("price", `exports.total = (q) => q.base * (1 - q.discount);`)
+	if err != nil {
+		t.Fatalf("注册模块失败: %v", err)
+	}
+	total, err := handle.CallAs[float64](ctx, page, "price", "total", priceQuery{Base: 100, Discount: 0.1})
+	if err != nil {
+		t.Fatalf("调用模块函数失败: %v", err)
+	}
+	if total != 90 {
+		t.Fatalf("模块函数返回值异常: %v", total)
+	}
+}
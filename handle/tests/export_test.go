@@ -0,0 +1,48 @@
+package handle_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestExportPDFAndMHTML(t *testing.T) {
+	page := browser.NewTabPage("export", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+	if _, err := page.Evaluate(`() => { document.title = "导出"; document.body.innerHTML = "<h1>导出测试</h1>"; }`); err != nil {
+		t.Fatalf("准备页面失败: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "page.pdf")
+	var writer bytes.Buffer
+	data, err := page.PDF(ctx, handle.PDFOptions{Landscape: true, PrintBackground: true, Path: path, Writer: &writer})
+	if err != nil {
+		t.Fatalf("导出 PDF 失败: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF")) {
+		t.Fatalf("导出内容不是 PDF: %q", data[:min(len(data), 16)])
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil || !bytes.Equal(saved, data) || !bytes.Equal(writer.Bytes(), data) {
+		t.Fatalf("PDF 未正确写入文件和 Writer: %v", err)
+	}
+
+	mhtml, err := page.MHTML(ctx)
+	if err != nil {
+		t.Fatalf("导出 MHTML 失败: %v", err)
+	}
+	if !bytes.Contains(mhtml, []byte("MIME-Version")) || !bytes.Contains(mhtml, []byte("multipart/related")) {
+		t.Fatalf("导出内容不是 MHTML: %q", mhtml[:min(len(mhtml), 200)])
+	}
+}
@@ -0,0 +1,57 @@
+package handle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type productItem struct {
+	Name   string  `sel:".name"`
+	Price  float64 `sel:".price" attr:"data-value" parse:"float"`
+	Stock  int     `sel:".stock"`
+	OnSale bool    `sel:".sale"`
+}
+
+type productList struct {
+	Title string        `sel:"h1"`
+	Link  string        `sel:"a.more" attr:"href"`
+	Items []productItem `sel:".item"`
+	Tags  []string      `sel:".tag"`
+}
+
+func TestExtract(t *testing.T) {
+	page := browser.NewTabPage("extract", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := page.Evaluate(`() => { document.body.innerHTML = ` + "`" + `
+		<h1>  商品  列表 </h1>
+		<a class="more" href="/more">更多</a>
+		<div class="item"><span class="name">A</span><span class="price" data-value="¥1,299.50"></span><span class="stock">库存 12 件</span><i class="sale"></i></div>
+		<div class="item"><span class="name">B</span><span class="price" data-value="8"></span></div>
+		<b class="tag">新品</b><b class="tag">热卖</b>` + "`" + ` }`)
+	if err != nil {
+		t.Fatalf("设置页面内容失败: %v", err)
+	}
+
+	var out productList
+	if err := page.Extract(ctx, &out); err != nil {
+		t.Fatalf("提取失败: %v", err)
+	}
+	if out.Title != "商品 列表" || out.Link != "/more" || len(out.Items) != 2 || len(out.Tags) != 2 {
+		t.Fatalf("提取结果异常: %+v", out)
+	}
+	first, second := out.Items[0], out.Items[1]
+	if first.Name != "A" || first.Price != 1299.5 || first.Stock != 12 || !first.OnSale {
+		t.Fatalf("第一项异常: %+v", first)
+	}
+	if second.Name != "B" || second.Price != 8 || second.Stock != 0 || second.OnSale {
+		t.Fatalf("第二项异常: %+v", second)
+	}
+}
@@ -0,0 +1,90 @@
+package handle_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestRecordAndReplayHAR(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><h1 id="title">录制的页面</h1></body></html>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	target := server.URL + "/"
+	path := filepath.Join(t.TempDir(), "page.har")
+
+	page := browser.NewTabPage("har-record", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	recorder, err := page.RecordHAR(path, handle.HAROptions{})
+	if err != nil {
+		t.Fatalf("开始录制失败: %v", err)
+	}
+	if err := page.Goto(target); err != nil {
+		t.Fatalf("访问页面失败: %v", err)
+	}
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("保存 HAR 失败: %v", err)
+	}
+	page.Close()
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("读取 HAR 失败: %v", err)
+	}
+	var har struct {
+		Log struct {
+			Entries []struct {
+				Request struct {
+					URL         string `json:"url"`
+					HTTPVersion string `json:"httpVersion"`
+				} `json:"request"`
+				Response struct {
+					Content struct {
+						Text string `json:"text"`
+					} `json:"content"`
+				} `json:"response"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.Unmarshal(data, &har); err != nil {
+		t.Fatalf("解析 HAR 失败: %v", err)
+	}
+	if len(har.Log.Entries) == 0 || har.Log.Entries[0].Request.URL != target {
+		t.Fatalf("HAR 未录制页面请求: %+v", har.Log.Entries)
+	}
+	if entry := har.Log.Entries[0]; entry.Request.HTTPVersion != "unknown" || entry.Response.Content.Text == "" {
+		t.Fatalf("HAR 记录异常: %+v", entry)
+	}
+
+	replay := browser.NewTabPage("har-replay", "about:blank")
+	if replay == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer replay.Close()
+	stop, err := replay.ReplayHAR(path, handle.HARReplayOptions{})
+	if err != nil {
+		t.Fatalf("加载 HAR 失败: %v", err)
+	}
+	defer stop()
+	if err := replay.Goto(target); err != nil {
+		t.Fatalf("回放页面失败: %v", err)
+	}
+	title, err := replay.Evaluate("() => document.getElementById('title').textContent")
+	if err != nil || title != "录制的页面" {
+		t.Fatalf("回放内容异常: %v, %v", title, err)
+	}
+}
@@ -0,0 +1,95 @@
+package handle_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestNavigateRedirectChain(t *testing.T) {
+	var (
+		locker  sync.Mutex
+		headers = make(map[string]string)
+		referer string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locker.Lock()
+		headers[r.URL.Path] = r.Header.Get("X-Trace")
+		if r.URL.Path == "/final" {
+			referer = r.Header.Get("Referer")
+		}
+		locker.Unlock()
+
+		switch r.URL.Path {
+		case "/start":
+			http.Redirect(w, r, "/middle", http.StatusFound)
+		case "/middle":
+			http.Redirect(w, r, "/final", http.StatusMovedPermanently)
+		case "/final":
+			w.Header().Set("X-Final", "yes")
+			fmt.Fprint(w, `<html><body><script>
+				setTimeout(() => { const p = document.createElement("p"); p.id = "late"; p.textContent = "ok"; document.body.appendChild(p); }, 200);
+			</script></body></html>`)
+		case "/api":
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	page := browser.NewTabPage("navigate", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result, err := page.Navigate(ctx, server.URL+"/start", handle.NavigateOptions{
+		WaitUntil: handle.WaitDOMContentLoaded,
+		Selector:  "#late",
+		Referer:   "https://referer.example/",
+		Headers:   map[string]string{"X-Trace": "nav-1"},
+	})
+	if err != nil {
+		t.Fatalf("导航失败: %v", err)
+	}
+
+	if result.URL != server.URL+"/final" || result.Status != 200 || !result.OK() || result.Headers["x-final"] != "yes" {
+		t.Fatalf("导航结果异常: %+v", result)
+	}
+	want := []handle.RedirectHop{{URL: server.URL + "/start", Status: 302}, {URL: server.URL + "/middle", Status: 301}}
+	if len(result.Redirects) != len(want) || result.Redirects[0] != want[0] || result.Redirects[1] != want[1] {
+		t.Fatalf("重定向链异常: %+v", result.Redirects)
+	}
+	if result.Timings.Total <= 0 {
+		t.Fatalf("导航耗时异常: %+v", result.Timings)
+	}
+	if visible, err := page.Evaluate(`() => document.getElementById("late") !== null`); err != nil || visible != true {
+		t.Fatalf("导航应等待元素出现: %v, %v", visible, err)
+	}
+
+	if _, err := page.Evaluate(`() => fetch("/api").then(r => r.status)`); err != nil {
+		t.Fatalf("请求接口失败: %v", err)
+	}
+	locker.Lock()
+	defer locker.Unlock()
+	for _, path := range []string{"/start", "/middle", "/final"} {
+		if headers[path] != "nav-1" {
+			t.Fatalf("导航请求 %s 未携带请求头: %q", path, headers[path])
+		}
+	}
+	if headers["/api"] != "" {
+		t.Fatalf("导航结束后的请求不应携带导航请求头")
+	}
+	if referer != "https://referer.example/" {
+		t.Fatalf("Referer 异常: %q", referer)
+	}
+}
@@ -0,0 +1,60 @@
+package handle_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestPaginateNext(t *testing.T) {
+	page := browser.NewTabPage("paginate", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// 三页数据，每页两项，最后一页的下一页按钮禁用；第二页重复第一页的一项
+	_, err := page.Evaluate(`() => {
+		const pages = [["a", "b"], ["b", "c"], ["d", "e"]];
+		let current = 0;
+		const render = () => {
+			document.body.innerHTML = pages[current].map(v => '<li class="item">' + v + '</li>').join("") +
+				'<button id="next"' + (current === pages.length - 1 ? ' disabled' : '') + '>下一页</button>';
+			document.querySelector("#next").onclick = () => setTimeout(() => { current++; render(); }, 100);
+		};
+		render();
+	}`)
+	if err != nil {
+		t.Fatalf("设置页面内容失败: %v", err)
+	}
+
+	paginator, err := handle.Paginate(ctx, page, handle.PaginateOptions[string]{
+		Mode:         handle.PaginateNext,
+		NextSelector: "#next",
+		Extract: func(ctx context.Context, tab handle.TabPage) ([]string, error) {
+			return tab.ExtractList(ctx, "body")
+		},
+		Key: func(item string) string { return item },
+	})
+	if err != nil {
+		t.Fatalf("创建翻页器失败: %v", err)
+	}
+
+	var got []string
+	for item := range paginator.Items() {
+		if item.Item != "下一页" {
+			got = append(got, item.Item)
+		}
+	}
+	if err := paginator.Err(); err != nil {
+		t.Fatalf("翻页失败: %v", err)
+	}
+	if len(got) != 5 || got[4] != "e" || paginator.Cursor().Page != 3 {
+		t.Fatalf("翻页结果异常: %v, %+v", got, paginator.Cursor())
+	}
+}
@@ -0,0 +1,65 @@
+package handle_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestPermissionsWithoutTabs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body>permissions</body></html>`))
+	}))
+	defer server.Close()
+
+	session, err := browser.NewSession("permissions", handle.SessionOptions{})
+	if err != nil {
+		t.Fatalf("创建会话失败: %v", err)
+	}
+	defer session.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 会话内还没有标签页
+	if err := session.GrantPermissions(server.URL, handle.PermissionGeolocation); err != nil {
+		t.Fatalf("授予权限失败: %v", err)
+	}
+	if err := session.DenyPermissions(ctx, server.URL, handle.PermissionNotifications); err != nil {
+		t.Fatalf("拒绝权限失败: %v", err)
+	}
+
+	page := session.NewTabPage("permissions", server.URL)
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	query := `async (name) => (await navigator.permissions.query({ name })).state`
+	states := map[handle.Permission]string{
+		handle.PermissionGeolocation:   "granted",
+		handle.PermissionNotifications: "denied",
+	}
+	for permission, want := range states {
+		var state string
+		if err := page.EvaluateInto(ctx, &state, query, string(permission)); err != nil {
+			t.Fatalf("查询权限失败: %v", err)
+		}
+		if state != want {
+			t.Fatalf("权限 %s 状态异常: %s, 期望 %s", permission, state, want)
+		}
+	}
+
+	if err := session.ClearPermissions(ctx); err != nil {
+		t.Fatalf("清除权限失败: %v", err)
+	}
+	var state string
+	if err := page.EvaluateInto(ctx, &state, query, string(handle.PermissionGeolocation)); err != nil {
+		t.Fatalf("查询权限失败: %v", err)
+	}
+	if state != "prompt" {
+		t.Fatalf("清除后权限状态异常: %s", state)
+	}
+}
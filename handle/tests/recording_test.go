@@ -0,0 +1,80 @@
+package handle_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestSessionTraceAndVideo(t *testing.T) {
+	traceDir, videoDir := t.TempDir(), t.TempDir()
+	session, err := browser.NewSession("recording", handle.SessionOptions{
+		Trace: &handle.TraceOptions{Dir: traceDir, Screenshots: true, Snapshots: true},
+		Video: &handle.VideoOptions{Dir: videoDir, Width: 320, Height: 240},
+	})
+	if err != nil {
+		t.Fatalf("创建会话失败: %v", err)
+	}
+	page := session.NewTabPage("recording", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	if _, err := page.Evaluate(`() => { document.body.innerHTML = "<h1>录制</h1>"; }`); err != nil {
+		t.Fatalf("执行脚本失败: %v", err)
+	}
+	if err := session.Close(); err != nil {
+		t.Fatalf("关闭会话失败: %v", err)
+	}
+
+	var trace, video string
+	for _, file := range session.Recordings() {
+		switch {
+		case strings.HasPrefix(file, traceDir) && strings.HasSuffix(file, ".zip"):
+			trace = file
+		case strings.HasPrefix(file, videoDir) && strings.HasSuffix(file, ".webm"):
+			video = file
+		}
+	}
+	if trace == "" || video == "" {
+		t.Fatalf("录制文件缺失: %v", session.Recordings())
+	}
+	data, err := os.ReadFile(trace)
+	if err != nil || !bytes.HasPrefix(data, []byte("PK")) {
+		t.Fatalf("追踪文件不是 zip: %v", err)
+	}
+	if info, err := os.Stat(video); err != nil || info.Size() == 0 {
+		t.Fatalf("视频文件为空: %v", err)
+	}
+}
+
+func TestTraceRetainOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	trace := &handle.TraceOptions{Dir: dir, Retention: handle.RetainOnFailure}
+	for _, failed := range []bool{false, true} {
+		session, err := browser.NewSession("retain-on-failure", handle.SessionOptions{Trace: trace})
+		if err != nil {
+			t.Fatalf("创建会话失败: %v", err)
+		}
+		if page := session.NewTabPage("retain-on-failure", "about:blank"); page == nil {
+			t.Fatalf("创建标签页失败")
+		}
+		if failed {
+			session.MarkFailed(os.ErrNotExist)
+		}
+		if err := session.Close(); err != nil {
+			t.Fatalf("关闭会话失败: %v", err)
+		}
+		files, _ := filepath.Glob(filepath.Join(dir, "*.zip"))
+		want := 0
+		if failed {
+			want = 1
+		}
+		if len(files) != want || len(session.Recordings()) != want {
+			t.Fatalf("失败=%v 时追踪文件数异常: %v", failed, files)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package handle_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestRouteAbortAndFulfill(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><script src="/heavy.js"></script></body></html>`)
+		case "/heavy.js":
+			fmt.Fprint(w, `window.heavyLoaded = true;`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	page := browser.NewTabPage("route", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	if err := page.Route("block-js", handle.RouteMatcher{URLGlob: "**/*.js"}, handle.AbortRoute("blockedbyclient")); err != nil {
+		t.Fatalf("添加路由规则失败: %v", err)
+	}
+	err := page.Route("stub-api", handle.RouteMatcher{URLGlob: "**/api/*", Methods: []string{"GET"}},
+		handle.FulfillFunc(func(req *handle.RouteRequest) (*handle.RouteResponse, error) {
+			return &handle.RouteResponse{Status: 200, ContentType: "application/json", Body: []byte(`{"ok":true}`)}, nil
+		}))
+	if err != nil {
+		t.Fatalf("添加路由规则失败: %v", err)
+	}
+
+	if err := page.Goto(server.URL); err != nil {
+		t.Fatalf("访问页面失败: %v", err)
+	}
+	loaded, err := page.Evaluate("() => window.heavyLoaded === true")
+	if err != nil || loaded != false {
+		t.Fatalf("脚本未被拦截: %v, %v", loaded, err)
+	}
+	body, err := page.Evaluate("async () => (await fetch('/api/status')).json()")
+	if err != nil {
+		t.Fatalf("请求模拟接口失败: %v", err)
+	}
+	if m, ok := body.(map[string]any); !ok || m["ok"] != true {
+		t.Fatalf("模拟接口返回异常: %v", body)
+	}
+
+	if err := page.Unroute("block-js"); err != nil {
+		t.Fatalf("移除路由规则失败: %v", err)
+	}
+	if names := page.RouteNames(); len(names) != 1 || names[0] != "stub-api" {
+		t.Fatalf("路由规则列表异常: %v", names)
+	}
+}
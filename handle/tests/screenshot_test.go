@@ -0,0 +1,85 @@
+package handle_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func decode_png(t *testing.T, data []byte) image.Image {
+	t.Helper()
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("截图不是有效的 PNG: %v", err)
+	}
+	return img
+}
+
+func TestScreenshotModes(t *testing.T) {
+	page := browser.NewTabPage("screenshot", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+	_, err := page.Evaluate(`() => {
+		document.body.style.margin = "0";
+		document.body.innerHTML = '<div id="box" style="width:120px;height:80px;background:#0000ff"></div>' +
+			'<div style="height:3000px"></div>';
+	}`)
+	if err != nil {
+		t.Fatalf("准备页面失败: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// 默认上下文沿用系统的像素比，尺寸按元素截图换算
+	element := decode_png(t, must_screenshot(t, ctx, page, handle.ScreenshotOptions{Selector: "#box"}))
+	scale := element.Bounds().Dx() / 120
+	if scale < 1 || element.Bounds().Dx() != 120*scale || element.Bounds().Dy() != 80*scale {
+		t.Fatalf("元素截图尺寸异常: %v", element.Bounds())
+	}
+
+	viewport := decode_png(t, must_screenshot(t, ctx, page, handle.ScreenshotOptions{}))
+	full := decode_png(t, must_screenshot(t, ctx, page, handle.ScreenshotOptions{FullPage: true}))
+	if full.Bounds().Dy() <= viewport.Bounds().Dy() || full.Bounds().Dy() < 3000*scale {
+		t.Fatalf("整页截图高度异常: 视口 %d, 整页 %d", viewport.Bounds().Dy(), full.Bounds().Dy())
+	}
+
+	clip := decode_png(t, must_screenshot(t, ctx, page, handle.ScreenshotOptions{Clip: &handle.ClipRect{X: 10, Y: 10, Width: 50, Height: 40}}))
+	if clip.Bounds().Dx() != 50*scale || clip.Bounds().Dy() != 40*scale {
+		t.Fatalf("区域截图尺寸异常: %v", clip.Bounds())
+	}
+
+	masked := decode_png(t, must_screenshot(t, ctx, page, handle.ScreenshotOptions{Selector: "#box", Mask: []string{"#box"}, MaskColor: "#ff0000"}))
+	r, g, b, _ := masked.At(masked.Bounds().Dx()/2, masked.Bounds().Dy()/2).RGBA()
+	if r>>8 < 200 || g>>8 > 50 || b>>8 > 50 {
+		t.Fatalf("遮盖区域颜色异常: %d %d %d", r>>8, g>>8, b>>8)
+	}
+
+	path := filepath.Join(t.TempDir(), "shot.jpg")
+	data := must_screenshot(t, ctx, page, handle.ScreenshotOptions{Path: path, Quality: 80})
+	if !bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}) {
+		t.Fatalf("按扩展名应输出 JPEG")
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil || !bytes.Equal(saved, data) {
+		t.Fatalf("截图文件未正确保存: %v", err)
+	}
+}
+
+func must_screenshot(t *testing.T, ctx context.Context, page handle.TabPage, opts handle.ScreenshotOptions) []byte {
+	t.Helper()
+	data, err := page.Screenshot(ctx, opts)
+	if err != nil {
+		t.Fatalf("截图失败: %v", err)
+	}
+	return data
+}
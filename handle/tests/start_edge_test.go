@@ -2,6 +2,7 @@ package handle_test
 
 import (
 	"log"
+	"os"
 	"testing"
 	"time"
 
@@ -13,25 +14,22 @@ var (
 	browser handle.Browser
 )
 
-func init() {
+// TestMain 启动或连接 Edge，所有测试结束后关闭，测试之间不依赖文件顺序
+func TestMain(m *testing.M) {
 	log.SetFlags(log.LstdFlags | log.Lshortfile) // 时间戳 + 文件名行号
 
 	edge, err := handle.Edge().Listen(port)
 	if err != nil {
-		panic(err)
+		log.Fatalf("启动 Edge 失败: %v", err)
 	}
 	browser = edge
-}
 
-func dispose() {
-	if browser != nil {
-		browser.Close()
-	}
+	code := m.Run()
+	browser.Close()
+	os.Exit(code)
 }
 
 func TestVisitYFW(t *testing.T) {
-	t.Cleanup(dispose)
-
 	page := browser.DefaultPage()
 	if page == nil {
 		t.Fatalf("未找到默认标签页")
@@ -45,6 +43,4 @@ func TestVisitYFW(t *testing.T) {
 	}
 	time.Sleep(5 * time.Second)
 	page.Close()
-	time.Sleep(2 * time.Second)
-	browser.Close()
 }
@@ -0,0 +1,62 @@
+package handle_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtractTable(t *testing.T) {
+	page := browser.NewTabPage("table", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := page.Evaluate(`() => { document.body.innerHTML = ` + "`" + `
+		<table id="orders">
+			<thead>
+				<tr><th rowspan="2">单号</th><th colspan="2">金额</th></tr>
+				<tr><th>含税</th><th>不含税</th></tr>
+			</thead>
+			<tbody>
+				<tr><td>A001</td><td>113</td><td>100</td></tr>
+				<tr><td rowspan="2">A002</td><td colspan="2">免税</td></tr>
+				<tr><td>50</td><td>50</td></tr>
+			</tbody>
+			<tfoot><tr><td>合计</td><td>163</td><td>150</td></tr></tfoot>
+		</table>` + "`" + ` }`)
+	if err != nil {
+		t.Fatalf("设置页面内容失败: %v", err)
+	}
+
+	table, err := page.ExtractTable(ctx, "#orders")
+	if err != nil {
+		t.Fatalf("提取表格失败: %v", err)
+	}
+	if strings.Join(table.Headers, ",") != "单号,金额 / 含税,金额 / 不含税" {
+		t.Fatalf("表头异常: %q", table.Headers)
+	}
+	if len(table.Rows) != 3 || len(table.Footer) != 1 {
+		t.Fatalf("行数异常: %q", table.Rows)
+	}
+	if strings.Join(table.Rows[1], ",") != "A002,免税,免税" || strings.Join(table.Rows[2], ",") != "A002,50,50" {
+		t.Fatalf("合并单元格展开异常: %q", table.Rows)
+	}
+	if table.Records()[0]["金额 / 含税"] != "113" {
+		t.Fatalf("记录异常: %v", table.Records()[0])
+	}
+
+	var csv bytes.Buffer
+	if err := table.WriteCSV(&csv); err != nil {
+		t.Fatalf("输出 CSV 失败: %v", err)
+	}
+	if !strings.HasPrefix(csv.String(), "单号,金额 / 含税,金额 / 不含税\nA001,113,100\n") {
+		t.Fatalf("CSV 异常: %s", csv.String())
+	}
+}
@@ -0,0 +1,86 @@
+package handle_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+const uploadPage = `() => {
+	document.body.innerHTML = '<input id="file" type="file" multiple><div id="zone" style="width:200px;height:100px">拖到这里</div>';
+	window.dropped = [];
+	const zone = document.getElementById("zone");
+	zone.addEventListener("dragover", (e) => e.preventDefault());
+	zone.addEventListener("drop", async (e) => {
+		e.preventDefault();
+		for (const file of e.dataTransfer.files) window.dropped.push(file.name + ":" + await file.text());
+	});
+}`
+
+const inputFiles = `async () => {
+	const files = [...document.getElementById("file").files];
+	return Promise.all(files.map(async (file) => file.name + ":" + await file.text()));
+}`
+
+func TestUploadAndDropFiles(t *testing.T) {
+	page := browser.NewTabPage("upload", "about:blank")
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+	if _, err := page.Evaluate(uploadPage); err != nil {
+		t.Fatalf("准备页面失败: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "disk.txt")
+	if err := os.WriteFile(path, []byte("磁盘文件"), 0644); err != nil {
+		t.Fatalf("写入临时文件失败: %v", err)
+	}
+	if err := page.UploadFiles(ctx, "#file", path); err != nil {
+		t.Fatalf("上传磁盘文件失败: %v", err)
+	}
+	var files []string
+	if err := page.EvaluateInto(ctx, &files, inputFiles); err != nil {
+		t.Fatalf("读取已选文件失败: %v", err)
+	}
+	if !slices.Equal(files, []string{"disk.txt:磁盘文件"}) {
+		t.Fatalf("上传的磁盘文件异常: %v", files)
+	}
+
+	err := page.UploadFileData(ctx, "#file",
+		handle.UploadFile{Name: "a.txt", Reader: strings.NewReader("内存 A")},
+		handle.UploadFile{Name: "b.json", MimeType: "application/json", Reader: strings.NewReader(`{"b":1}`)},
+	)
+	if err != nil {
+		t.Fatalf("上传内存数据失败: %v", err)
+	}
+	if err := page.EvaluateInto(ctx, &files, inputFiles); err != nil {
+		t.Fatalf("读取已选文件失败: %v", err)
+	}
+	if !slices.Equal(files, []string{"a.txt:内存 A", `b.json:{"b":1}`}) {
+		t.Fatalf("上传的内存数据异常: %v", files)
+	}
+
+	if err := page.DropFiles(ctx, "#zone", handle.UploadFile{Name: "drop.txt", Reader: strings.NewReader("拖放")}); err != nil {
+		t.Fatalf("拖放上传失败: %v", err)
+	}
+	var dropped []string
+	if _, err := page.WaitForFunction(ctx, "() => window.dropped.length > 0", 50*time.Millisecond); err != nil {
+		t.Fatalf("等待拖放事件失败: %v", err)
+	}
+	if err := page.EvaluateInto(ctx, &dropped, "() => window.dropped"); err != nil {
+		t.Fatalf("读取拖放结果失败: %v", err)
+	}
+	if !slices.Equal(dropped, []string{"drop.txt:拖放"}) {
+		t.Fatalf("拖放的文件异常: %v", dropped)
+	}
+}
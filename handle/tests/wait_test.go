@@ -0,0 +1,80 @@
+package handle_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/sssxyd/go-browser-handle/handle"
+)
+
+func TestWaitPrimitives(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, `<html><body><div id="spinner">加载中</div></body></html>`)
+		case "/slow":
+			time.Sleep(300 * time.Millisecond)
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	page := browser.NewTabPage("wait", server.URL)
+	if page == nil {
+		t.Fatalf("创建标签页失败")
+	}
+	defer page.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := page.Evaluate(`() => setTimeout(() => {
+		document.getElementById("spinner").remove();
+		const p = document.createElement("p");
+		p.textContent = "加载完成";
+		document.body.appendChild(p);
+		history.pushState({}, "", "/done?step=2");
+	}, 300)`)
+	if err != nil {
+		t.Fatalf("执行脚本失败: %v", err)
+	}
+	if err := page.WaitForText(ctx, "加载完成"); err != nil {
+		t.Fatalf("等待文本失败: %v", err)
+	}
+	if _, err := page.WaitForElement(ctx, "#spinner", handle.ElementHidden); err != nil {
+		t.Fatalf("等待元素消失失败: %v", err)
+	}
+	if err := page.WaitForURL(ctx, "**/done?*"); err != nil {
+		t.Fatalf("等待 URL 失败: %v", err)
+	}
+	if err := page.WaitForURLRegex(ctx, regexp.MustCompile(`step=\d$`)); err != nil {
+		t.Fatalf("等待 URL 正则失败: %v", err)
+	}
+
+	if _, err := page.Evaluate(`() => { window.count = 0; setTimeout(() => fetch("/slow").then(() => window.count = 3), 50); }`); err != nil {
+		t.Fatalf("执行脚本失败: %v", err)
+	}
+	value, err := page.WaitForFunction(ctx, "() => window.count >= 3 && window.count", 20*time.Millisecond)
+	if err != nil || value != 3 {
+		t.Fatalf("等待 JS 条件失败: %v, %v", value, err)
+	}
+	if err := page.WaitForNetworkIdle(ctx, 200*time.Millisecond); err != nil {
+		t.Fatalf("等待网络空闲失败: %v", err)
+	}
+
+	short, shortCancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer shortCancel()
+	err = page.WaitForText(short, "不会出现的文本")
+	var timeoutErr *handle.TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("超时应返回 *TimeoutError: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package handle
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// UploadFile 待上传的文件，Path 与 Reader 二选一
+type UploadFile struct {
+	Name     string    // 文件名，使用 Path 时可省略
+	MimeType string    // 文件类型，为空时按扩展名推断
+	Path     string    // 磁盘文件路径
+	Reader   io.Reader // 内存数据
+}
+
+// FileFromPath 从磁盘文件构造上传文件
+func FileFromPath(path string) UploadFile {
+	return UploadFile{Path: path}
+}
+
+// FileFromReader 从内存数据构造上传文件
+func FileFromReader(name string, mimeType string, reader io.Reader) UploadFile {
+	return UploadFile{Name: name, MimeType: mimeType, Reader: reader}
+}
+
+func (f UploadFile) load() (playwright.InputFile, error) {
+	name := f.Name
+	if name == "" {
+		name = filepath.Base(f.Path)
+	}
+	mimeType := f.MimeType
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filepath.Ext(name))
+	}
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	switch {
+	case f.Reader != nil:
+		data, err = io.ReadAll(f.Reader)
+	case f.Path != "":
+		data, err = os.ReadFile(f.Path)
+	default:
+		return playwright.InputFile{}, fmt.Errorf("上传文件 %s 未指定路径或数据", name)
+	}
+	if err != nil {
+		return playwright.InputFile{}, fmt.Errorf("无法读取上传文件 %s: %w", name, err)
+	}
+	return playwright.InputFile{Name: name, MimeType: mimeType, Buffer: data}, nil
+}
+
+func load_upload_files(files []UploadFile) ([]playwright.InputFile, error) {
+	items := make([]playwright.InputFile, 0, len(files))
+	for _, file := range files {
+		item, err := file.load()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// set_input_files 将文件设置到选择器指向的元素: <input type=file> 直接赋值，
+// 其他元素(如上传按钮)点击后接管弹出的文件选择框。files 为 []string 或 []playwright.InputFile
+func set_input_files(ctx context.Context, page playwright.Page, selector string, files any) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	locator := page.Locator(selector).First()
+	isInput, err := locator.Evaluate(`el => el.tagName === 'INPUT' && el.type === 'file'`, nil,
+		playwright.LocatorEvaluateOptions{Timeout: ctx_timeout(ctx)})
+	if err != nil {
+		return fmt.Errorf("无法找到上传元素 %s: %w", selector, err)
+	}
+
+	if isInput == true {
+		if err := locator.SetInputFiles(files, playwright.LocatorSetInputFilesOptions{Timeout: ctx_timeout(ctx)}); err != nil {
+			return fmt.Errorf("设置上传文件失败: %w", err)
+		}
+		return nil
+	}
+
+	chooser, err := page.ExpectFileChooser(func() error {
+		return locator.Click(playwright.LocatorClickOptions{Timeout: ctx_timeout(ctx)})
+	}, playwright.PageExpectFileChooserOptions{Timeout: ctx_timeout(ctx)})
+	if err != nil {
+		return fmt.Errorf("等待文件选择框失败: %w", err)
+	}
+	if err := chooser.SetFiles(files, playwright.FileChooserSetFilesOptions{Timeout: ctx_timeout(ctx)}); err != nil {
+		return fmt.Errorf("设置上传文件失败: %w", err)
+	}
+	return nil
+}
+
+// drop_files 在目标元素上模拟拖放文件: 依次派发 dragenter、dragover、drop 事件
+func drop_files(ctx context.Context, page playwright.Page, selector string, files []UploadFile) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	items, err := load_upload_files(files)
+	if err != nil {
+		return err
+	}
+	payload := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		payload = append(payload, map[string]string{
+			"name":     item.Name,
+			"mimeType": item.MimeType,
+			"data":     base64.StdEncoding.EncodeToString(item.Buffer),
+		})
+	}
+
+	_, err = page.Locator(selector).First().Evaluate(`(el, files) => {
+		const dataTransfer = new DataTransfer();
+		for (const file of files) {
+			const binary = atob(file.data);
+			const bytes = new Uint8Array(binary.length);
+			for (let i = 0; i < binary.length; i++) {
+				bytes[i] = binary.charCodeAt(i);
+			}
+			dataTransfer.items.add(new File([bytes], file.name, { type: file.mimeType }));
+		}
+		const rect = el.getBoundingClientRect();
+		const init = {
+			bubbles: true,
+			cancelable: true,
+			dataTransfer,
+			clientX: rect.left + rect.width / 2,
+			clientY: rect.top + rect.height / 2,
+		};
+		for (const type of ['dragenter', 'dragover', 'drop']) {
+			el.dispatchEvent(new DragEvent(type, init));
+		}
+	}`, payload, playwright.LocatorEvaluateOptions{Timeout: ctx_timeout(ctx)})
+	if err != nil {
+		return fmt.Errorf("拖放上传失败: %w", err)
+	}
+	return nil
+}
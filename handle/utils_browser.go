@@ -1,10 +1,13 @@
 package handle
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/playwright-community/playwright-go"
 )
@@ -117,3 +120,46 @@ func extract_domain_from_url(urlStr string) (string, error) {
 	}
 	return parts[len(parts)-2] + "." + parts[len(parts)-1], nil
 }
+
+// glob_to_regexp 将 URL 通配符转换为正则: ** 匹配任意字符, * 匹配除 / 外的字符, {a,b} 匹配任一分支
+func glob_to_regexp(glob string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	inGroup := false
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '{':
+			inGroup = true
+			sb.WriteString("(?:")
+		case c == '}' && inGroup:
+			inGroup = false
+			sb.WriteString(")")
+		case c == ',' && inGroup:
+			sb.WriteString("|")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// ctx_timeout 将上下文剩余时间换算为 Playwright 的超时参数(毫秒)，没有截止时间时返回 nil 使用默认超时
+func ctx_timeout(ctx context.Context) *float64 {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+	remaining := time.Until(deadline).Milliseconds()
+	if remaining < 1 {
+		remaining = 1
+	}
+	return playwright.Float(float64(remaining))
+}
@@ -0,0 +1,36 @@
+package handle
+
+import "testing"
+
+func TestGlobToRegexp(t *testing.T) {
+	cases := []struct {
+		glob  string
+		url   string
+		match bool
+	}{
+		{"**/*", "https://example.com/a/b.png", true},
+		{"**/*.png", "https://example.com/a/b.png", true},
+		{"**/*.png", "https://example.com/a/b.png?v=1", false},
+		{"https://example.com/*", "https://example.com/a", true},
+		{"https://example.com/*", "https://example.com/a/b", false},
+		{"https://example.com/**", "https://example.com/a/b", true},
+		{"**/*.{png,jpg}", "https://example.com/a.jpg", true},
+		{"**/*.{png,jpg}", "https://example.com/a.gif", false},
+		{"https://example.com/a?b", "https://example.com/a?b", true},
+		{"https://example.com/a?b", "https://example.com/axb", false},
+		{"https://example.com/a.b", "https://example.com/axb", false},
+		{"**/api/**", "https://example.com/v1/api/users", true},
+		{"**/商品/*", "https://example.com/商品/手机", true},
+		{"**/商品/*", "https://example.com/商城/手机", false},
+		{"https://example.com/{商品,订单}/**", "https://example.com/订单/1", true},
+	}
+	for _, c := range cases {
+		re, err := glob_to_regexp(c.glob)
+		if err != nil {
+			t.Fatalf("%s: 转换失败: %v", c.glob, err)
+		}
+		if got := re.MatchString(c.url); got != c.match {
+			t.Errorf("%s 匹配 %s = %v，期望 %v", c.glob, c.url, got, c.match)
+		}
+	}
+}
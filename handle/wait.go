@@ -0,0 +1,189 @@
+package handle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// ElementState 等待元素达到的状态
+type ElementState string
+
+const (
+	ElementAttached ElementState = "attached" // 出现在 DOM 中
+	ElementVisible  ElementState = "visible"  // 可见
+	ElementHidden   ElementState = "hidden"   // 不可见或不在 DOM 中
+	ElementDetached ElementState = "detached" // 从 DOM 中移除
+)
+
+// TimeoutError 等待超时的错误，ctx 到期和 Playwright 超时都会转换为该错误
+type TimeoutError struct {
+	Condition string        // 等待的条件
+	Elapsed   time.Duration // 已等待的时间
+	Err       error         // 原始错误
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("等待%s超时(%s): %v", e.Condition, e.Elapsed.Round(time.Millisecond), e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// wait_error 将超时类错误转换为 *TimeoutError
+func wait_error(condition string, started time.Time, err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, playwright.ErrTimeout) {
+		return &TimeoutError{Condition: condition, Elapsed: time.Since(started), Err: err}
+	}
+	return fmt.Errorf("等待%s失败: %w", condition, err)
+}
+
+// wait_with_ctx 在协程中执行 Playwright 的等待，ctx 取消时立即返回
+func wait_with_ctx(ctx context.Context, condition string, wait func() error) error {
+	started := time.Now()
+	if err := ctx.Err(); err != nil {
+		return wait_error(condition, started, err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- wait()
+	}()
+	select {
+	case <-ctx.Done():
+		return wait_error(condition, started, ctx.Err())
+	case err := <-done:
+		return wait_error(condition, started, err)
+	}
+}
+
+func wait_for_element(ctx context.Context, page playwright.Page, tab *EdgeTabPage, selector string, state ElementState) (Element, error) {
+	if state == "" {
+		state = ElementVisible
+	}
+	var waitState *playwright.WaitForSelectorState
+	switch state {
+	case ElementAttached:
+		waitState = playwright.WaitForSelectorStateAttached
+	case ElementVisible:
+		waitState = playwright.WaitForSelectorStateVisible
+	case ElementHidden:
+		waitState = playwright.WaitForSelectorStateHidden
+	case ElementDetached:
+		waitState = playwright.WaitForSelectorStateDetached
+	default:
+		return nil, fmt.Errorf("未知的元素状态: %s", state)
+	}
+	locator := page.Locator(selector).First()
+	err := wait_with_ctx(ctx, fmt.Sprintf("元素 %s %s", selector, state), func() error {
+		return locator.WaitFor(playwright.LocatorWaitForOptions{State: waitState, Timeout: ctx_timeout(ctx)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newEdgeElement(selector, locator, tab), nil
+}
+
+func wait_for_text(ctx context.Context, page playwright.Page, text string) error {
+	return wait_with_ctx(ctx, fmt.Sprintf("文本 %q 出现", text), func() error {
+		_, err := page.WaitForFunction("text => !!document.body && document.body.innerText.includes(text)", text, playwright.PageWaitForFunctionOptions{
+			Timeout: ctx_timeout(ctx),
+		})
+		return err
+	})
+}
+
+func wait_for_url(ctx context.Context, page playwright.Page, pattern *regexp.Regexp) error {
+	return wait_with_ctx(ctx, fmt.Sprintf("URL 匹配 %s", pattern), func() error {
+		return page.WaitForURL(pattern, playwright.PageWaitForURLOptions{
+			Timeout:   ctx_timeout(ctx),
+			WaitUntil: playwright.WaitUntilStateCommit,
+		})
+	})
+}
+
+func wait_for_function(ctx context.Context, page playwright.Page, expression string, polling time.Duration, arg any) (any, error) {
+	var result any
+	err := wait_with_ctx(ctx, "JS 条件成立", func() error {
+		options := playwright.PageWaitForFunctionOptions{Timeout: ctx_timeout(ctx)}
+		if polling > 0 {
+			options.Polling = float64(polling.Milliseconds())
+		}
+		handle, err := page.WaitForFunction(expression, arg, options)
+		if err != nil {
+			return err
+		}
+		defer handle.Dispose()
+		result, err = handle.JSONValue()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// wait_for_network_idle 等待没有进行中的请求并持续 idle，只统计开始等待之后发出的请求
+func wait_for_network_idle(ctx context.Context, started *eventHub[playwright.Request], finished *eventHub[playwright.Request], idle time.Duration) error {
+	begin := time.Now()
+	condition := fmt.Sprintf("网络空闲 %s", idle)
+	if err := ctx.Err(); err != nil {
+		return wait_error(condition, begin, err)
+	}
+
+	var locker sync.Mutex
+	inflight := make(map[playwright.Request]struct{})
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+	unsubscribeStarted := started.subscribe(func(request playwright.Request) {
+		locker.Lock()
+		inflight[request] = struct{}{}
+		locker.Unlock()
+		notify()
+	})
+	defer unsubscribeStarted()
+	unsubscribeFinished := finished.subscribe(func(request playwright.Request) {
+		locker.Lock()
+		delete(inflight, request)
+		locker.Unlock()
+		notify()
+	})
+	defer unsubscribeFinished()
+
+	pending := func() int {
+		locker.Lock()
+		defer locker.Unlock()
+		return len(inflight)
+	}
+
+	timer := time.NewTimer(idle)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return wait_error(condition, begin, ctx.Err())
+		case <-changed:
+			timer.Stop()
+			if pending() == 0 {
+				timer.Reset(idle)
+			}
+		case <-timer.C:
+			if pending() == 0 {
+				return nil
+			}
+		}
+	}
+}